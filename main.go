@@ -5,6 +5,7 @@ import (
 	"reflect"
 
 	devfilepkg "github.com/devfile/library/pkg/devfile"
+	"github.com/devfile/library/pkg/devfile/generator"
 	"github.com/devfile/library/pkg/devfile/parser"
 	v2 "github.com/devfile/library/pkg/devfile/parser/data/v2"
 	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
@@ -57,13 +58,11 @@ func main() {
 			}
 		}
 
-		var err error
-		metadataAttr := devfile.Data.GetMetadata().Attributes
-		dockerfilePath := metadataAttr.GetString("alpha.build-dockerfile", &err)
+		buildInfo, err := generator.GetDockerBuildInfo(devfile)
 		if err != nil {
 			fmt.Printf("err: %v\n", err)
 		}
-		fmt.Printf("dockerfilePath: %s\n", dockerfilePath)
+		fmt.Printf("dockerfilePath: %s\n", buildInfo.DockerfilePath)
 	}
 
 }
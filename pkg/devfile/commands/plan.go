@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/devfile/library/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// CommandStep is an exec or apply command of an execution plan
+type CommandStep struct {
+	Command   v1.Command
+	Component v1.Component
+	// WorkingDir is the working dir of the exec command, with $PROJECTS_ROOT and $PROJECT_SOURCE substituted
+	WorkingDir string
+	// Env is the env of the exec command, including the env of its container component, $PROJECTS_ROOT and $PROJECT_SOURCE
+	Env []v1.EnvVar
+}
+
+// CommandPlan is the execution plan of a command. The plan of an exec or apply command has a Step,
+// the plan of a composite command has the plans of its commands, to run in order or in parallel
+type CommandPlan struct {
+	CommandID string
+	Step      *CommandStep
+	Parallel  bool
+	Commands  []CommandPlan
+}
+
+// Steps returns the steps of the plan, in order
+func (p CommandPlan) Steps() []CommandStep {
+	if p.Step != nil {
+		return []CommandStep{*p.Step}
+	}
+	var steps []CommandStep
+	for _, command := range p.Commands {
+		steps = append(steps, command.Steps()...)
+	}
+	return steps
+}
+
+// ExpandCommand returns the execution plan of the command with the given id among the commands, with the composite commands
+// expanded recursively. The steps of the plan only hold their command, their component, working dir and env are not resolved.
+// An error is returned if a command is not defined or if a composite command references itself
+func ExpandCommand(commands []v1.Command, commandID string) (CommandPlan, error) {
+	return getCommandPlan(getCommandMap(commands), commandID, nil)
+}
+
+// GetCommandPlan returns the execution plan of the command with the given id, with the composite commands expanded recursively.
+// An error is returned if a command or a component is not defined or if a composite command references itself
+func GetCommandPlan(devfileObj parser.DevfileObj, commandID string) (CommandPlan, error) {
+	commands, err := devfileObj.Data.GetCommands(common.DevfileOptions{})
+	if err != nil {
+		return CommandPlan{}, err
+	}
+	components, err := devfileObj.Data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return CommandPlan{}, err
+	}
+	projects, err := devfileObj.Data.GetProjects(common.DevfileOptions{})
+	if err != nil {
+		return CommandPlan{}, err
+	}
+
+	plan, err := ExpandCommand(commands, commandID)
+	if err != nil {
+		return CommandPlan{}, err
+	}
+
+	var resolve func(plan *CommandPlan) error
+	resolve = func(plan *CommandPlan) error {
+		if plan.Step != nil {
+			return resolveCommandStep(plan.Step, components, projects)
+		}
+		for i := range plan.Commands {
+			if err := resolve(&plan.Commands[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := resolve(&plan); err != nil {
+		return CommandPlan{}, err
+	}
+	return plan, nil
+}
+
+// getCommandMap returns the commands mapped by their lowercase id
+func getCommandMap(commands []v1.Command) map[string]v1.Command {
+	commandMap := make(map[string]v1.Command)
+	for _, command := range commands {
+		commandMap[strings.ToLower(command.Id)] = command
+	}
+	return commandMap
+}
+
+// getCommandPlan expands the command into an execution plan, the composite commands are expanded recursively.
+// parents holds the ids of the composite commands being expanded, to report the cycles. The steps of the plan
+// only hold their command, their component, working dir and env are resolved by GetCommandPlan
+func getCommandPlan(commandMap map[string]v1.Command, commandID string, parents []string) (CommandPlan, error) {
+	commandID = strings.ToLower(commandID)
+	command, ok := commandMap[commandID]
+	if !ok {
+		err := &common.FieldNotFoundError{Field: "command", Name: commandID}
+		if len(parents) > 0 {
+			return CommandPlan{}, errors.Wrapf(err, "the composite command %s references an undefined command", parents[len(parents)-1])
+		}
+		return CommandPlan{}, err
+	}
+	if util.In(parents, commandID) {
+		return CommandPlan{}, fmt.Errorf("the command %s references itself through %s", commandID, strings.Join(append(parents, commandID), " -> "))
+	}
+
+	plan := CommandPlan{CommandID: commandID}
+	switch {
+	case command.Exec != nil, command.Apply != nil:
+		plan.Step = &CommandStep{Command: command}
+	case command.Composite != nil:
+		plan.Parallel = command.Composite.Parallel
+		parents = append(parents[:len(parents):len(parents)], commandID)
+		for _, subCommandID := range command.Composite.Commands {
+			subPlan, err := getCommandPlan(commandMap, subCommandID, parents)
+			if err != nil {
+				return CommandPlan{}, err
+			}
+			plan.Commands = append(plan.Commands, subPlan)
+		}
+	default:
+		return CommandPlan{}, fmt.Errorf("the command %s is not an exec, apply or composite command", commandID)
+	}
+	return plan, nil
+}
+
+// resolveCommandStep sets the component, the working dir and the env of the step. The working dir has $PROJECTS_ROOT and
+// $PROJECT_SOURCE substituted and the env is the env of the container component overridden by the env of the exec command
+func resolveCommandStep(step *CommandStep, components []v1.Component, projects []v1.Project) error {
+	componentName := ""
+	if step.Command.Exec != nil {
+		componentName = step.Command.Exec.Component
+	} else {
+		componentName = step.Command.Apply.Component
+	}
+
+	for _, component := range components {
+		if component.Name == componentName {
+			step.Component = component
+			break
+		}
+	}
+	if step.Component.Name == "" {
+		return errors.Wrapf(&common.FieldNotFoundError{Field: "component", Name: componentName}, "the command %s references an undefined component", step.Command.Id)
+	}
+	if step.Command.Exec == nil {
+		return nil
+	}
+	if step.Component.Container == nil {
+		return fmt.Errorf("the component %s of the exec command %s is not a container component", componentName, step.Command.Id)
+	}
+
+	projectsRoot := common.GetProjectsRoot(step.Component.Container.SourceMapping)
+	projectSource, err := common.GetProjectSource(projectsRoot, projects)
+	if err != nil {
+		return err
+	}
+	step.WorkingDir = strings.NewReplacer(
+		"${"+common.EnvProjectsRoot+"}", projectsRoot,
+		"$"+common.EnvProjectsRoot, projectsRoot,
+		"${"+common.EnvProjectSource+"}", projectSource,
+		"$"+common.EnvProjectSource, projectSource,
+	).Replace(step.Command.Exec.WorkingDir)
+
+	envs := []v1.EnvVar{
+		{Name: common.EnvProjectsRoot, Value: projectsRoot},
+		{Name: common.EnvProjectSource, Value: projectSource},
+	}
+	for _, env := range append(step.Component.Container.Env, step.Command.Exec.Env...) {
+		overridden := false
+		for i := range envs {
+			if envs[i].Name == env.Name {
+				envs[i].Value = env.Value
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			envs = append(envs, env)
+		}
+	}
+	step.Env = envs
+	return nil
+}
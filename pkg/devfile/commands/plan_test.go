@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/devfile/library/pkg/testingutil"
+)
+
+func TestGetCommandPlan(t *testing.T) {
+
+	components := []v1.Component{
+		{
+			Name: "runtime",
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{
+						Image:         "runtime-image",
+						SourceMapping: "/src",
+						Env: []v1.EnvVar{
+							testingutil.GetFakeEnv("MODE", "dev"),
+							testingutil.GetFakeEnv("PORT", "8080"),
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "manifests",
+			ComponentUnion: v1.ComponentUnion{
+				Kubernetes: &v1.KubernetesComponent{},
+			},
+		},
+	}
+	commands := []v1.Command{
+		{
+			Id: "build",
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					CommandLine: "make",
+					Component:   "runtime",
+					WorkingDir:  "${PROJECT_SOURCE}/app",
+					Env:         []v1.EnvVar{testingutil.GetFakeEnv("MODE", "debug")},
+				},
+			},
+		},
+		{
+			Id: "test",
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					CommandLine: "make test",
+					Component:   "runtime",
+					WorkingDir:  "$PROJECTS_ROOT",
+				},
+			},
+		},
+		{
+			Id: "deploy",
+			CommandUnion: v1.CommandUnion{
+				Apply: &v1.ApplyCommand{
+					Component: "manifests",
+				},
+			},
+		},
+		{
+			Id: "checks",
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{
+					Commands: []string{"test", "deploy"},
+					Parallel: true,
+				},
+			},
+		},
+		{
+			Id: "all",
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{
+					Commands: []string{"Build", "checks"},
+				},
+			},
+		},
+		{
+			Id: "loop",
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{
+					Commands: []string{"all", "loop"},
+				},
+			},
+		},
+		{
+			Id: "dangling",
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{
+					Commands: []string{"build", "missing"},
+				},
+			},
+		},
+		{
+			Id: "orphan",
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					CommandLine: "true",
+					Component:   "missing",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		commandID     string
+		wantSteps     []string
+		wantWorkDirs  []string
+		wantBuildEnvs []v1.EnvVar
+		wantErr       bool
+	}{
+		{
+			name:         "Case 1: Nested composite commands",
+			commandID:    "all",
+			wantSteps:    []string{"build", "test", "deploy"},
+			wantWorkDirs: []string{"/src/test-project/app", "/src", ""},
+			wantBuildEnvs: []v1.EnvVar{
+				testingutil.GetFakeEnv(common.EnvProjectsRoot, "/src"),
+				testingutil.GetFakeEnv(common.EnvProjectSource, "/src/test-project"),
+				testingutil.GetFakeEnv("MODE", "debug"),
+				testingutil.GetFakeEnv("PORT", "8080"),
+			},
+		},
+		{
+			name:      "Case 2: Composite command cycle",
+			commandID: "loop",
+			wantErr:   true,
+		},
+		{
+			name:      "Case 3: Dangling command reference",
+			commandID: "dangling",
+			wantErr:   true,
+		},
+		{
+			name:      "Case 4: Undefined command",
+			commandID: "missing",
+			wantErr:   true,
+		},
+		{
+			name:      "Case 5: Undefined component",
+			commandID: "orphan",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devObj := parser.DevfileObj{
+				Data: &testingutil.TestDevfileData{
+					Components: components,
+					Commands:   commands,
+				},
+			}
+
+			plan, err := GetCommandPlan(devObj, tt.commandID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestGetCommandPlan error: %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if plan.CommandID != "all" || plan.Parallel || len(plan.Commands) != 2 {
+				t.Errorf("TestGetCommandPlan error: unexpected plan %v", plan)
+				return
+			}
+			if checks := plan.Commands[1]; checks.CommandID != "checks" || !checks.Parallel || len(checks.Commands) != 2 {
+				t.Errorf("TestGetCommandPlan error: unexpected parallel plan %v", checks)
+			}
+
+			steps := plan.Steps()
+			var stepIDs, workDirs []string
+			for _, step := range steps {
+				stepIDs = append(stepIDs, step.Command.Id)
+				workDirs = append(workDirs, step.WorkingDir)
+			}
+			if !reflect.DeepEqual(stepIDs, tt.wantSteps) {
+				t.Errorf("TestGetCommandPlan error: steps mismatch - got: %v, wanted: %v", stepIDs, tt.wantSteps)
+			}
+			if !reflect.DeepEqual(workDirs, tt.wantWorkDirs) {
+				t.Errorf("TestGetCommandPlan error: working dirs mismatch - got: %v, wanted: %v", workDirs, tt.wantWorkDirs)
+			}
+			if !reflect.DeepEqual(steps[0].Env, tt.wantBuildEnvs) {
+				t.Errorf("TestGetCommandPlan error: env mismatch - got: %v, wanted: %v", steps[0].Env, tt.wantBuildEnvs)
+			}
+			if steps[2].Component.Name != "manifests" {
+				t.Errorf("TestGetCommandPlan error: apply component mismatch - got: %s, wanted: manifests", steps[2].Component.Name)
+			}
+		})
+	}
+}
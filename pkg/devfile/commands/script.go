@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+)
+
+// GetExecScript returns the shell script running the command line of an exec command with the env in the working dir.
+// The env values are single quoted, the working dir is double quoted so that its env variables are expanded
+func GetExecScript(commandLine, workingDir string, env []v1.EnvVar) string {
+	var script []string
+	for _, envVar := range env {
+		script = append(script, fmt.Sprintf("export %s='%s'", envVar.Name, strings.ReplaceAll(envVar.Value, "'", `'\''`)))
+	}
+	if workingDir != "" {
+		script = append(script, fmt.Sprintf(`cd "%s"`, strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(workingDir)))
+	}
+	script = append(script, commandLine)
+	return strings.Join(script, " && ")
+}
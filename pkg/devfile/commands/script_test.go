@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"testing"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+)
+
+func TestGetExecScript(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		workingDir string
+		env        []v1.EnvVar
+		want       string
+	}{
+		{
+			name:       "Case 1: Env and working dir with a variable",
+			workingDir: "$PROJECT_SOURCE",
+			env:        []v1.EnvVar{{Name: "MODE", Value: "it's dev"}},
+			want:       `export MODE='it'\''s dev' && cd "$PROJECT_SOURCE" && npm start`,
+		},
+		{
+			name:       "Case 2: Working dir with spaces and quotes",
+			workingDir: `/projects/my "app"; rm -rf /`,
+			want:       `cd "/projects/my \"app\"; rm -rf /" && npm start`,
+		},
+		{
+			name: "Case 3: No env and working dir",
+			want: "npm start",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetExecScript("npm start", tt.workingDir, tt.env); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
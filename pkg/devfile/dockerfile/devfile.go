@@ -0,0 +1,90 @@
+package dockerfile
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/devfile/library/pkg/devfile/parser/data"
+)
+
+// runCommandID is the id of the run command of the generated devfiles
+const runCommandID = "run"
+
+// GenerateDevfile returns the builder of a devfile at the path with a container component with the name running the image of
+// the Dockerfile, with an endpoint for each exposed port and the environment variables of the Dockerfile. If the Dockerfile
+// has a CMD or an ENTRYPOINT, the devfile has a default run command running it in the working directory of the Dockerfile
+func GenerateDevfile(dockerfile *Dockerfile, path, name string) *parser.DevfileBuilder {
+	container := v1.ContainerComponent{
+		Container: v1.Container{
+			Image: dockerfile.Image,
+			Env:   dockerfile.Env,
+		},
+	}
+	for _, port := range dockerfile.Ports {
+		container.Endpoints = append(container.Endpoints, v1.Endpoint{
+			Name:       fmt.Sprintf("port-%d-%s", port.Number, port.Protocol),
+			TargetPort: port.Number,
+			Protocol:   v1.EndpointProtocol(port.Protocol),
+		})
+	}
+
+	builder := parser.NewDevfile(path, data.APIVersion200.String()).
+		WithMetadata(name, "").
+		AddContainer(name, container)
+
+	commandLine := dockerfile.GetCommandLine()
+	if commandLine == "" {
+		return builder
+	}
+	return builder.AddCommand(v1.Command{
+		Id: runCommandID,
+		CommandUnion: v1.CommandUnion{
+			Exec: &v1.ExecCommand{
+				LabeledCommand: v1.LabeledCommand{
+					BaseCommand: v1.BaseCommand{
+						Group: &v1.CommandGroup{
+							Kind:      v1.RunCommandGroupKind,
+							IsDefault: true,
+						},
+					},
+				},
+				CommandLine: commandLine,
+				Component:   name,
+				WorkingDir:  dockerfile.WorkDir,
+			},
+		},
+	})
+}
+
+// GetCommandLine returns the shell command line running the ENTRYPOINT and the CMD of the Dockerfile,
+// the CMD is ignored with a shell form ENTRYPOINT
+func (d *Dockerfile) GetCommandLine() string {
+	command := d.Entrypoint
+	if !isShellForm(d.Entrypoint) {
+		command = append(append([]string(nil), d.Entrypoint...), d.Cmd...)
+	}
+	if isShellForm(command) {
+		return command[2]
+	}
+
+	var args []string
+	for _, arg := range command {
+		args = append(args, quoteArg(arg))
+	}
+	return strings.Join(args, " ")
+}
+
+// isShellForm returns true if the command is a shell form command run with /bin/sh -c
+func isShellForm(command []string) bool {
+	return len(command) == 3 && command[0] == "/bin/sh" && command[1] == "-c"
+}
+
+// quoteArg quotes the argument for a shell if needed
+func quoteArg(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\$`&|;<>()*?[]#~!{}") {
+		return arg
+	}
+	return "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,134 @@
+package dockerfile
+
+import (
+	"testing"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/testingutil/filesystem"
+)
+
+func TestGenerateDevfile(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		dockerfile  *Dockerfile
+		wantDevfile string
+	}{
+		{
+			name: "case 1: Dockerfile with a command",
+			dockerfile: &Dockerfile{
+				Image:   "node:12-slim",
+				Ports:   []Port{{Number: 3000, Protocol: "tcp"}, {Number: 9229, Protocol: "udp"}},
+				Env:     []v1.EnvVar{{Name: "NODE_ENV", Value: "production"}},
+				WorkDir: "/project",
+				Cmd:     []string{"npm", "start"},
+			},
+			wantDevfile: `commands:
+- exec:
+    commandLine: npm start
+    component: nodejs
+    group:
+      isDefault: true
+      kind: run
+    workingDir: /project
+  id: run
+components:
+- container:
+    endpoints:
+    - name: port-3000-tcp
+      protocol: tcp
+      targetPort: 3000
+    - name: port-9229-udp
+      protocol: udp
+      targetPort: 9229
+    env:
+    - name: NODE_ENV
+      value: production
+    image: node:12-slim
+  name: nodejs
+metadata:
+  name: nodejs
+schemaVersion: 2.0.0
+`,
+		},
+		{
+			name: "case 2: Dockerfile without command",
+			dockerfile: &Dockerfile{
+				Image: "alpine",
+			},
+			wantDevfile: `components:
+- container:
+    image: alpine
+  name: nodejs
+metadata:
+  name: nodejs
+schemaVersion: 2.0.0
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := filesystem.NewFakeFs()
+			d, err := GenerateDevfile(tt.dockerfile, "devfile.yaml", "nodejs").WithFs(fs).Write()
+			if err != nil {
+				t.Fatalf("TestGenerateDevfile() unexpected error %v", err)
+			}
+			content, err := fs.ReadFile(d.Ctx.GetAbsPath())
+			if err != nil {
+				t.Fatalf("TestGenerateDevfile() unexpected error %v", err)
+			}
+			if string(content) != tt.wantDevfile {
+				t.Errorf("TestGenerateDevfile() devfile mismatch - wanted:\n%s\ngot:\n%s", tt.wantDevfile, content)
+			}
+		})
+	}
+}
+
+func TestGetCommandLine(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		entrypoint []string
+		cmd        []string
+		want       string
+	}{
+		{
+			name: "case 1: exec form command",
+			cmd:  []string{"npm", "run", "start:dev"},
+			want: "npm run start:dev",
+		},
+		{
+			name: "case 2: shell form command",
+			cmd:  []string{"/bin/sh", "-c", "npm install && npm start"},
+			want: "npm install && npm start",
+		},
+		{
+			name:       "case 3: exec form entrypoint and command",
+			entrypoint: []string{"python", "-m"},
+			cmd:        []string{"http.server", "--bind", "0.0.0.0"},
+			want:       "python -m http.server --bind 0.0.0.0",
+		},
+		{
+			name:       "case 4: shell form entrypoint ignoring the command",
+			entrypoint: []string{"/bin/sh", "-c", "./run.sh"},
+			cmd:        []string{"--debug"},
+			want:       "./run.sh",
+		},
+		{
+			name: "case 5: arguments quoted for the shell",
+			cmd:  []string{"echo", "hello world", "it's", ""},
+			want: `echo 'hello world' 'it'\''s' ''`,
+		},
+		{
+			name: "case 6: no command",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Dockerfile{Entrypoint: tt.entrypoint, Cmd: tt.cmd}
+			if got := d.GetCommandLine(); got != tt.want {
+				t.Errorf("TestGetCommandLine() mismatch - wanted: %q, got: %q", tt.want, got)
+			}
+		})
+	}
+}
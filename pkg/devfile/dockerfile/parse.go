@@ -0,0 +1,373 @@
+package dockerfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/testingutil/filesystem"
+	"github.com/pkg/errors"
+)
+
+// instructions are the instructions of the Dockerfile reference
+var instructions = map[string]bool{
+	"ADD": true, "ARG": true, "CMD": true, "COPY": true, "ENTRYPOINT": true, "ENV": true, "EXPOSE": true, "FROM": true,
+	"HEALTHCHECK": true, "LABEL": true, "MAINTAINER": true, "ONBUILD": true, "RUN": true, "SHELL": true, "STOPSIGNAL": true,
+	"USER": true, "VOLUME": true, "WORKDIR": true,
+}
+
+// Dockerfile holds the instructions of the last build stage of a Dockerfile, which builds the image of the Dockerfile
+type Dockerfile struct {
+	// Image is the base image of the build stage
+	Image string
+	// Ports are the ports exposed by the build stage
+	Ports []Port
+	// Env are the environment variables of the build stage
+	Env []v1.EnvVar
+	// WorkDir is the absolute working directory of the build stage
+	WorkDir string
+	// Entrypoint is the entrypoint of the image, a shell form entrypoint is run with /bin/sh -c
+	Entrypoint []string
+	// Cmd is the command of the image, a shell form command is run with /bin/sh -c
+	Cmd []string
+}
+
+// Port is a port exposed by a Dockerfile
+type Port struct {
+	Number   int
+	Protocol string
+}
+
+// ParseError error returned if a line of a Dockerfile cannot be parsed
+type ParseError struct {
+	// line number of the instruction, starting at 1
+	Line int
+	// reason of the error
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// stage is a build stage of a Dockerfile
+type stage struct {
+	Dockerfile
+	name string
+	args map[string]string
+}
+
+// ParseFile parses the Dockerfile at the path
+func ParseFile(fs filesystem.Filesystem, path string) (*Dockerfile, error) {
+	content, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read Dockerfile from path '%s'", path)
+	}
+	return Parse(content)
+}
+
+// Parse parses the FROM, EXPOSE, ENV, WORKDIR, CMD and ENTRYPOINT instructions of the Dockerfile content,
+// the other instructions are checked but ignored. If an instruction cannot be parsed, error out with a ParseError
+func Parse(content []byte) (*Dockerfile, error) {
+	globalArgs := make(map[string]string)
+	var stages []*stage
+	var current *stage
+
+	lines, err := readInstructions(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		keyword, args := splitInstruction(line.text)
+		instruction := strings.ToUpper(keyword)
+		if !instructions[instruction] {
+			return nil, &ParseError{Line: line.number, Msg: fmt.Sprintf("unknown instruction %s", keyword)}
+		}
+		if args == "" {
+			return nil, &ParseError{Line: line.number, Msg: fmt.Sprintf("%s requires at least one argument", instruction)}
+		}
+		if current == nil && instruction != "FROM" && instruction != "ARG" {
+			return nil, &ParseError{Line: line.number, Msg: fmt.Sprintf("the first instruction must be FROM, found %s", instruction)}
+		}
+
+		switch instruction {
+		case "FROM":
+			current, err = parseFrom(line.number, args, globalArgs, stages)
+			if err != nil {
+				return nil, err
+			}
+			stages = append(stages, current)
+		case "ARG":
+			stageArgs := globalArgs
+			if current != nil {
+				stageArgs = current.args
+			}
+			name, value := args, ""
+			if i := strings.Index(name, "="); i >= 0 {
+				name, value = name[:i], strings.Trim(name[i+1:], `"'`)
+			}
+			stageArgs[name] = value
+		case "EXPOSE":
+			ports, err := parsePorts(current.expand(args))
+			if err != nil {
+				return nil, &ParseError{Line: line.number, Msg: err.Error()}
+			}
+			current.Ports = append(current.Ports, ports...)
+		case "ENV":
+			env, err := parseEnv(args)
+			if err != nil {
+				return nil, &ParseError{Line: line.number, Msg: err.Error()}
+			}
+			current.setEnv(env)
+		case "WORKDIR":
+			workDir := current.expand(args)
+			if !path.IsAbs(workDir) {
+				workDir = path.Join("/", current.WorkDir, workDir)
+			}
+			current.WorkDir = path.Clean(workDir)
+		case "CMD":
+			current.Cmd = parseCommand(args)
+		case "ENTRYPOINT":
+			current.Entrypoint = parseCommand(args)
+		}
+	}
+
+	if current == nil {
+		return nil, fmt.Errorf("no FROM instruction found in the Dockerfile")
+	}
+	return &current.Dockerfile, nil
+}
+
+// line is an instruction of a Dockerfile, with its continuation lines
+type line struct {
+	number int
+	text   string
+}
+
+// readInstructions returns the instructions of the Dockerfile content, without the comments and the blank lines,
+// with their continuation lines joined and the number of their first line
+func readInstructions(content []byte) ([]line, error) {
+	var lines []line
+	var current *line
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(text, "#") || text == "" {
+			continue
+		}
+		if current == nil {
+			current = &line{number: number}
+		}
+		if strings.HasSuffix(text, `\`) {
+			current.text += strings.TrimSuffix(text, `\`) + " "
+			continue
+		}
+		current.text = strings.TrimSpace(current.text + text)
+		lines = append(lines, *current)
+		current = nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read Dockerfile")
+	}
+	if current != nil {
+		current.text = strings.TrimSpace(current.text)
+		lines = append(lines, *current)
+	}
+	return lines, nil
+}
+
+// splitInstruction returns the keyword and the arguments of the instruction
+func splitInstruction(text string) (string, string) {
+	i := strings.IndexAny(text, " \t")
+	if i < 0 {
+		return text, ""
+	}
+	return text[:i], strings.TrimSpace(text[i:])
+}
+
+// parseFrom returns the build stage of the FROM instruction arguments at the line number, a stage built from a previous stage
+// inherits its instructions. If the arguments are invalid, error out with a ParseError
+func parseFrom(number int, args string, globalArgs map[string]string, stages []*stage) (*stage, error) {
+	var words []string
+	for _, word := range strings.Fields(args) {
+		if !strings.HasPrefix(word, "--") {
+			words = append(words, word)
+		}
+	}
+	if len(words) != 1 && (len(words) != 3 || !strings.EqualFold(words[1], "AS")) {
+		return nil, &ParseError{Line: number, Msg: fmt.Sprintf("invalid FROM arguments %q, expected FROM <image> [AS <name>]", args)}
+	}
+
+	image := os.Expand(words[0], func(name string) string {
+		return globalArgs[name]
+	})
+	s := &stage{
+		Dockerfile: Dockerfile{Image: image},
+		args:       make(map[string]string),
+	}
+	for _, previous := range stages {
+		if previous.name != "" && previous.name == strings.ToLower(image) {
+			s.Dockerfile = previous.Dockerfile
+			s.Ports = append([]Port(nil), previous.Ports...)
+			s.Env = append([]v1.EnvVar(nil), previous.Env...)
+		}
+	}
+	if len(words) == 3 {
+		s.name = strings.ToLower(words[2])
+	}
+	return s, nil
+}
+
+// expand replaces the references to the environment variables and the arguments of the stage in the value
+func (s *stage) expand(value string) string {
+	return os.Expand(value, func(name string) string {
+		for _, env := range s.Env {
+			if env.Name == name {
+				return env.Value
+			}
+		}
+		return s.args[name]
+	})
+}
+
+// setEnv sets the environment variables of the stage, an existing environment variable is updated in place
+func (s *stage) setEnv(env []v1.EnvVar) {
+	for _, envVar := range env {
+		found := false
+		for i := range s.Env {
+			if s.Env[i].Name == envVar.Name {
+				s.Env[i].Value = envVar.Value
+				found = true
+			}
+		}
+		if !found {
+			s.Env = append(s.Env, envVar)
+		}
+	}
+}
+
+// parsePorts parses the EXPOSE instruction arguments, <port>[/<protocol>] or <start>-<end>[/<protocol>],
+// the default protocol is tcp
+func parsePorts(args string) ([]Port, error) {
+	var ports []Port
+	for _, arg := range strings.Fields(args) {
+		portRange, protocol := arg, "tcp"
+		if i := strings.Index(arg, "/"); i >= 0 {
+			portRange, protocol = arg[:i], strings.ToLower(arg[i+1:])
+		}
+		if protocol != "tcp" && protocol != "udp" {
+			return nil, fmt.Errorf("invalid protocol %s of the port %s, the protocol must be tcp or udp", protocol, arg)
+		}
+
+		start, end := portRange, portRange
+		if i := strings.Index(portRange, "-"); i >= 0 {
+			start, end = portRange[:i], portRange[i+1:]
+		}
+		startNumber, err := parsePortNumber(start)
+		if err != nil {
+			return nil, err
+		}
+		endNumber, err := parsePortNumber(end)
+		if err != nil {
+			return nil, err
+		}
+		if endNumber < startNumber {
+			return nil, fmt.Errorf("invalid port range %s", portRange)
+		}
+		for number := startNumber; number <= endNumber; number++ {
+			ports = append(ports, Port{Number: number, Protocol: protocol})
+		}
+	}
+	return ports, nil
+}
+
+func parsePortNumber(port string) (int, error) {
+	number, err := strconv.Atoi(port)
+	if err != nil || number < 1 || number > 65535 {
+		return 0, fmt.Errorf("invalid port number %s", port)
+	}
+	return number, nil
+}
+
+// parseEnv parses the ENV instruction arguments, <key>=<value> ... or the legacy <key> <value> form
+func parseEnv(args string) ([]v1.EnvVar, error) {
+	words, err := splitWords(args)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(words[0], "=") {
+		key, value := splitInstruction(args)
+		return []v1.EnvVar{{Name: key, Value: value}}, nil
+	}
+
+	var env []v1.EnvVar
+	for _, word := range words {
+		i := strings.Index(word, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid environment variable %q, expected <key>=<value>", word)
+		}
+		env = append(env, v1.EnvVar{Name: word[:i], Value: word[i+1:]})
+	}
+	return env, nil
+}
+
+// splitWords splits the arguments in words separated by whitespaces, with the quotes removed and the escaped characters unescaped
+func splitWords(args string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, c := range args {
+		switch {
+		case escaped:
+			word.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+			inWord = true
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+			continue
+		default:
+			word.WriteRune(c)
+		}
+		inWord = true
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in %q", args)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// parseCommand parses the CMD and ENTRYPOINT instructions arguments, in the JSON exec form or in the shell form
+func parseCommand(args string) []string {
+	if strings.HasPrefix(args, "[") {
+		var command []string
+		if err := json.Unmarshal([]byte(args), &command); err == nil {
+			return command
+		}
+	}
+	return []string{"/bin/sh", "-c", args}
+}
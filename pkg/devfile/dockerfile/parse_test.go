@@ -0,0 +1,190 @@
+package dockerfile
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/testingutil/filesystem"
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestParseFile(t *testing.T) {
+
+	nodejsDockerfile := &Dockerfile{
+		Image:   "node:12-slim",
+		Env:     []v1.EnvVar{{Name: "NODE_ENV", Value: "production"}},
+		WorkDir: "/project",
+		Cmd:     []string{"npm", "start"},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		want     *Dockerfile
+		wantLine int
+	}{
+		{
+			name: "case 1: multi-stage Dockerfile",
+			path: "Dockerfile",
+			want: nodejsDockerfile,
+		},
+		{
+			name: "case 2: Dockerfile with comments",
+			path: "DockerfileWithComment",
+			want: nodejsDockerfile,
+		},
+		{
+			name: "case 3: Dockerfile with whitespaces",
+			path: "DockerfileWithWhitespace",
+			want: nodejsDockerfile,
+		},
+		{
+			name:     "case 4: Dockerfile with an illegal line",
+			path:     "DockerfileInvalid",
+			wantLine: 1,
+		},
+		{
+			name:     "case 5: Dockerfile with an invalid FROM instruction",
+			path:     "DockerfileInvalidFROM",
+			wantLine: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFile(filesystem.DefaultFs{}, "../../../tests/dockerfiles/"+tt.path)
+			if tt.wantLine != 0 {
+				if parseErr, ok := err.(*ParseError); !ok || parseErr.Line != tt.wantLine {
+					t.Errorf("TestParseFile() expected a ParseError at line %d, got %v", tt.wantLine, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("TestParseFile() unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestParseFile() Dockerfile mismatch: %s", pretty.Compare(tt.want, got))
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		content  string
+		want     *Dockerfile
+		wantErr  bool
+		wantLine int
+	}{
+		{
+			name: "case 1: all the instructions",
+			content: `ARG VERSION=14
+FROM --platform=linux/amd64 node:${VERSION} AS base
+ARG PORT=3000
+ENV NODE_ENV=production APP_NAME="my app" \
+    GREETING=hello\ world
+ENV LEGACY some value
+EXPOSE ${PORT} 9229/udp 8000-8002/tcp
+WORKDIR /opt
+WORKDIR app
+entrypoint ["node"]
+CMD ["server.js"]
+`,
+			want: &Dockerfile{
+				Image: "node:14",
+				Ports: []Port{{Number: 3000, Protocol: "tcp"}, {Number: 9229, Protocol: "udp"}, {Number: 8000, Protocol: "tcp"}, {Number: 8001, Protocol: "tcp"}, {Number: 8002, Protocol: "tcp"}},
+				Env: []v1.EnvVar{
+					{Name: "NODE_ENV", Value: "production"},
+					{Name: "APP_NAME", Value: "my app"},
+					{Name: "GREETING", Value: "hello world"},
+					{Name: "LEGACY", Value: "some value"},
+				},
+				WorkDir:    "/opt/app",
+				Entrypoint: []string{"node"},
+				Cmd:        []string{"server.js"},
+			},
+		},
+		{
+			name: "case 2: stage built from a previous stage",
+			content: `FROM golang:1.15 AS builder
+ENV CGO_ENABLED=0
+EXPOSE 8080
+FROM alpine AS runtime
+FROM builder
+ENV CGO_ENABLED=1
+CMD go run main.go
+`,
+			want: &Dockerfile{
+				Image: "golang:1.15",
+				Ports: []Port{{Number: 8080, Protocol: "tcp"}},
+				Env:   []v1.EnvVar{{Name: "CGO_ENABLED", Value: "1"}},
+				Cmd:   []string{"/bin/sh", "-c", "go run main.go"},
+			},
+		},
+		{
+			name:     "case 3: instruction before FROM",
+			content:  "# syntax\n\nRUN make\nFROM alpine\n",
+			wantErr:  true,
+			wantLine: 3,
+		},
+		{
+			name:     "case 4: invalid port after a continuation line",
+			content:  "FROM alpine\nRUN apk add \\\n    curl\nEXPOSE 80 http\n",
+			wantErr:  true,
+			wantLine: 4,
+		},
+		{
+			name:     "case 5: invalid port protocol",
+			content:  "FROM alpine\nEXPOSE 80/sctp\n",
+			wantErr:  true,
+			wantLine: 2,
+		},
+		{
+			name:     "case 6: unterminated quote",
+			content:  "FROM alpine\nENV NAME=\"value\n",
+			wantErr:  true,
+			wantLine: 2,
+		},
+		{
+			name:     "case 7: instruction without arguments",
+			content:  "FROM alpine\nWORKDIR\n",
+			wantErr:  true,
+			wantLine: 2,
+		},
+		{
+			name:     "case 8: invalid FROM arguments",
+			content:  "FROM alpine latest\n",
+			wantErr:  true,
+			wantLine: 1,
+		},
+		{
+			name:    "case 9: no FROM instruction",
+			content: "# empty Dockerfile\n",
+			wantErr: true,
+		},
+		{
+			name:     "case 10: invalid FROM arguments of a later stage",
+			content:  "FROM golang:1.15 AS builder\nRUN make\n\nFROM alpine AS runtime latest\n",
+			wantErr:  true,
+			wantLine: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TestParse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantLine != 0 {
+				if parseErr, ok := err.(*ParseError); !ok || parseErr.Line != tt.wantLine {
+					t.Errorf("TestParse() expected a ParseError at line %d, got %v", tt.wantLine, err)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestParse() Dockerfile mismatch: %s", pretty.Compare(tt.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package dockerfile
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/devfile/api/pkg/attributes"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/devfile/library/pkg/testingutil/filesystem"
+)
+
+const (
+	// BuildDockerfileAttribute is the devfile metadata attribute with the path of the Dockerfile building the devfile image,
+	// relative to the devfile directory
+	BuildDockerfileAttribute = "alpha.build-dockerfile"
+
+	// BuildContextAttribute is the devfile metadata attribute with the path of the build context directory,
+	// relative to the devfile directory. The default build context is the devfile directory
+	BuildContextAttribute = "alpha.build-context"
+
+	// BuildArgsAttribute is the devfile metadata attribute with the build arguments of the Dockerfile,
+	// either a map or a list of <name>=<value>
+	BuildArgsAttribute = "alpha.build-args"
+)
+
+// DockerBuildErrorReason is the reason of a DockerBuildError
+type DockerBuildErrorReason string
+
+const (
+	// DockerBuildNotSetReason is the reason of the error if the Dockerfile attribute is not set
+	DockerBuildNotSetReason DockerBuildErrorReason = "NotSet"
+	// DockerBuildInvalidPathReason is the reason of the error if the path is not a relative path
+	DockerBuildInvalidPathReason DockerBuildErrorReason = "InvalidPath"
+	// DockerBuildRemoteReason is the reason of the error if the path is a URL, remote Dockerfiles and build contexts are not supported
+	DockerBuildRemoteReason DockerBuildErrorReason = "Remote"
+	// DockerBuildOutsideProjectReason is the reason of the error if the path is outside of the devfile directory
+	DockerBuildOutsideProjectReason DockerBuildErrorReason = "OutsideProject"
+	// DockerBuildNotFoundReason is the reason of the error if the path doesn't exist
+	DockerBuildNotFoundReason DockerBuildErrorReason = "NotFound"
+	// DockerBuildWrongTypeReason is the reason of the error if the Dockerfile is a directory or the build context is not a directory
+	DockerBuildWrongTypeReason DockerBuildErrorReason = "WrongType"
+	// DockerBuildInvalidDockerfileReason is the reason of the error if the Dockerfile cannot be parsed
+	DockerBuildInvalidDockerfileReason DockerBuildErrorReason = "InvalidDockerfile"
+)
+
+// DockerBuildError error returned if the Dockerfile or the build context of the devfile metadata is invalid
+type DockerBuildError struct {
+	// metadata attribute of the invalid path, BuildDockerfileAttribute or BuildContextAttribute
+	Attribute string
+	// invalid path
+	Path   string
+	Reason DockerBuildErrorReason
+	// line of the invalid instruction of an invalid Dockerfile, starting at 1
+	Line int
+	// underlying error
+	Err error
+}
+
+func (e *DockerBuildError) Error() string {
+	switch e.Reason {
+	case DockerBuildNotSetReason:
+		return fmt.Sprintf("the devfile metadata has no %s attribute", e.Attribute)
+	case DockerBuildRemoteReason:
+		return fmt.Sprintf("the %s attribute %s is a URL, only a path relative to the devfile directory is supported", e.Attribute, e.Path)
+	case DockerBuildOutsideProjectReason:
+		return fmt.Sprintf("the path %s of the %s attribute is outside of the devfile directory", e.Path, e.Attribute)
+	case DockerBuildNotFoundReason:
+		return fmt.Sprintf("the path %s of the %s attribute is not found", e.Path, e.Attribute)
+	case DockerBuildWrongTypeReason:
+		if e.Attribute == BuildContextAttribute {
+			return fmt.Sprintf("the build context %s is not a directory", e.Path)
+		}
+		return fmt.Sprintf("the Dockerfile %s is a directory", e.Path)
+	case DockerBuildInvalidDockerfileReason:
+		return fmt.Sprintf("invalid Dockerfile %s: %v", e.Path, e.Err)
+	}
+	return fmt.Sprintf("invalid %s attribute %q: %v", e.Attribute, e.Path, e.Err)
+}
+
+// ValidateDockerBuild checks the Dockerfile and the build context of the devfile metadata, resolved against the devfile directory:
+// they must be in the devfile directory, the build context must be a directory and the Dockerfile must be parsed successfully.
+// If not, error out with a DockerBuildError
+func ValidateDockerBuild(devfileObj parser.DevfileObj) error {
+	devfilePath := devfileObj.Ctx.GetAbsPath()
+	if devfilePath == "" {
+		return fmt.Errorf("unable to validate the Dockerfile of a devfile without local path")
+	}
+	fs := devfileObj.Ctx.GetFs()
+	if fs == nil {
+		fs = filesystem.DefaultFs{}
+	}
+	dockerfilePath, buildContext, err := GetDockerBuildPaths(devfileObj.Data.GetMetadata().Attributes)
+	if err != nil {
+		return err
+	}
+
+	devfileDir := filepath.Dir(devfilePath)
+	if err := checkBuildPath(fs, devfileDir, BuildContextAttribute, buildContext, true); err != nil {
+		return err
+	}
+	if err := checkBuildPath(fs, devfileDir, BuildDockerfileAttribute, dockerfilePath, false); err != nil {
+		return err
+	}
+
+	_, err = ParseFile(fs, filepath.Join(devfileDir, filepath.FromSlash(dockerfilePath)))
+	if err != nil {
+		buildErr := &DockerBuildError{
+			Attribute: BuildDockerfileAttribute,
+			Path:      dockerfilePath,
+			Reason:    DockerBuildInvalidDockerfileReason,
+			Err:       err,
+		}
+		if parseErr, ok := err.(*ParseError); ok {
+			buildErr.Line = parseErr.Line
+		}
+		return buildErr
+	}
+	return nil
+}
+
+// GetDockerBuildPaths returns the clean slash separated paths of the Dockerfile and of the build context of the devfile metadata,
+// relative to the devfile directory. The default build context is the devfile directory. A URL, common in the devfiles of
+// the registries, is not downloaded and errors out with a DockerBuildError with the DockerBuildRemoteReason
+func GetDockerBuildPaths(metadataAttributes attributes.Attributes) (string, string, error) {
+	if !metadataAttributes.Exists(BuildDockerfileAttribute) {
+		return "", "", &DockerBuildError{
+			Attribute: BuildDockerfileAttribute,
+			Reason:    DockerBuildNotSetReason,
+		}
+	}
+	dockerfilePath, err := getBuildPath(metadataAttributes, BuildDockerfileAttribute)
+	if err != nil {
+		return "", "", err
+	}
+	if dockerfilePath == "." {
+		return "", "", &DockerBuildError{
+			Attribute: BuildDockerfileAttribute,
+			Reason:    DockerBuildInvalidPathReason,
+			Err:       fmt.Errorf("the Dockerfile path is empty"),
+		}
+	}
+
+	buildContext := "."
+	if metadataAttributes.Exists(BuildContextAttribute) {
+		if buildContext, err = getBuildPath(metadataAttributes, BuildContextAttribute); err != nil {
+			return "", "", err
+		}
+	}
+	return dockerfilePath, buildContext, nil
+}
+
+// getBuildPath returns the clean slash separated path of the attribute, relative to the devfile directory.
+// If the path is a URL, is absolute or is outside of the devfile directory, error out
+func getBuildPath(metadataAttributes attributes.Attributes, attribute string) (string, error) {
+	var err error
+	buildPath := metadataAttributes.GetString(attribute, &err)
+	if err != nil {
+		return "", &DockerBuildError{
+			Attribute: attribute,
+			Reason:    DockerBuildInvalidPathReason,
+			Err:       err,
+		}
+	}
+	if u, err := url.Parse(buildPath); err == nil && u.Scheme != "" && u.Host != "" {
+		return "", &DockerBuildError{
+			Attribute: attribute,
+			Path:      buildPath,
+			Reason:    DockerBuildRemoteReason,
+		}
+	}
+	if path.IsAbs(buildPath) || filepath.IsAbs(buildPath) {
+		return "", &DockerBuildError{
+			Attribute: attribute,
+			Path:      buildPath,
+			Reason:    DockerBuildInvalidPathReason,
+			Err:       fmt.Errorf("the path must be relative to the devfile directory"),
+		}
+	}
+	cleanPath := path.Clean(filepath.ToSlash(buildPath))
+	if cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+		return "", &DockerBuildError{
+			Attribute: attribute,
+			Path:      buildPath,
+			Reason:    DockerBuildOutsideProjectReason,
+		}
+	}
+	return cleanPath, nil
+}
+
+// checkBuildPath checks that the build path of the attribute exists in the devfile directory and is a directory or not.
+// With the default filesystem, the symbolic links of the build path must be in the devfile directory too
+func checkBuildPath(fs filesystem.Filesystem, devfileDir, attribute, buildPath string, isDir bool) error {
+	absPath := filepath.Join(devfileDir, filepath.FromSlash(buildPath))
+	info, err := fs.Stat(absPath)
+	if os.IsNotExist(err) {
+		return &DockerBuildError{
+			Attribute: attribute,
+			Path:      buildPath,
+			Reason:    DockerBuildNotFoundReason,
+		}
+	} else if err != nil {
+		return err
+	}
+	if info.IsDir() != isDir {
+		return &DockerBuildError{
+			Attribute: attribute,
+			Path:      buildPath,
+			Reason:    DockerBuildWrongTypeReason,
+		}
+	}
+
+	if _, ok := fs.(filesystem.DefaultFs); !ok {
+		return nil
+	}
+	realDir, err := filepath.EvalSymlinks(devfileDir)
+	if err != nil {
+		return err
+	}
+	realPath, err := filepath.EvalSymlinks(absPath)
+	if err != nil {
+		return err
+	}
+	if relPath, err := filepath.Rel(realDir, realPath); err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return &DockerBuildError{
+			Attribute: attribute,
+			Path:      buildPath,
+			Reason:    DockerBuildOutsideProjectReason,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package dockerfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devfile/library/pkg/devfile/parser"
+	devfileCtx "github.com/devfile/library/pkg/devfile/parser/context"
+	"github.com/devfile/library/pkg/testingutil"
+	"github.com/devfile/library/pkg/testingutil/filesystem"
+)
+
+func TestValidateDockerBuild(t *testing.T) {
+
+	fs := filesystem.NewFakeFs()
+	for _, name := range []string{"Dockerfile", "DockerfileInvalid", "DockerfileInvalidFROM"} {
+		content, err := filesystem.DefaultFs{}.ReadFile(filepath.Join("..", "..", "..", "tests", "dockerfiles", name))
+		if err != nil {
+			t.Fatalf("TestValidateDockerBuild() unexpected error %v", err)
+		}
+		if err := fs.WriteFile(filepath.Join("/project", "docker", name), content, 0644); err != nil {
+			t.Fatalf("TestValidateDockerBuild() unexpected error %v", err)
+		}
+	}
+	if err := fs.WriteFile("/Dockerfile", []byte("FROM alpine\n"), 0644); err != nil {
+		t.Fatalf("TestValidateDockerBuild() unexpected error %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		buildAttributes map[string]interface{}
+		wantReason      DockerBuildErrorReason
+		wantLine        int
+	}{
+		{
+			name: "case 1: valid Dockerfile and build context",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "docker/Dockerfile",
+				BuildContextAttribute:    "./docker/",
+			},
+		},
+		{
+			name:            "case 2: no Dockerfile attribute",
+			buildAttributes: map[string]interface{}{},
+			wantReason:      DockerBuildNotSetReason,
+		},
+		{
+			name: "case 3: Dockerfile outside of the project",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "docker/../../Dockerfile",
+			},
+			wantReason: DockerBuildOutsideProjectReason,
+		},
+		{
+			name: "case 4: absolute Dockerfile path",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "/project/docker/Dockerfile",
+			},
+			wantReason: DockerBuildInvalidPathReason,
+		},
+		{
+			name: "case 5: missing Dockerfile",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "Dockerfile",
+			},
+			wantReason: DockerBuildNotFoundReason,
+		},
+		{
+			name: "case 6: Dockerfile path of a directory",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "docker",
+			},
+			wantReason: DockerBuildWrongTypeReason,
+		},
+		{
+			name: "case 7: build context outside of the project",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "docker/Dockerfile",
+				BuildContextAttribute:    "..",
+			},
+			wantReason: DockerBuildOutsideProjectReason,
+		},
+		{
+			name: "case 8: build context path of a file",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "docker/Dockerfile",
+				BuildContextAttribute:    "docker/Dockerfile",
+			},
+			wantReason: DockerBuildWrongTypeReason,
+		},
+		{
+			name: "case 9: Dockerfile with an illegal line",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "docker/DockerfileInvalid",
+			},
+			wantReason: DockerBuildInvalidDockerfileReason,
+			wantLine:   1,
+		},
+		{
+			name: "case 10: Dockerfile with an invalid first instruction",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "docker/DockerfileInvalidFROM",
+			},
+			wantReason: DockerBuildInvalidDockerfileReason,
+			wantLine:   1,
+		},
+		{
+			name: "case 11: Dockerfile URL",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "https://raw.githubusercontent.com/odo-devfiles/nodejs-ex/main/Dockerfile",
+			},
+			wantReason: DockerBuildRemoteReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devObj := parser.DevfileObj{Data: testingutil.GetFakeDevfileDataWithMetadataAttributes(tt.buildAttributes)}
+			devObj.Ctx = devfileCtx.FakeContext(fs, "/project/devfile.yaml")
+
+			err := ValidateDockerBuild(devObj)
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Errorf("TestValidateDockerBuild() unexpected error %v", err)
+				}
+				return
+			}
+			buildErr, ok := err.(*DockerBuildError)
+			if !ok {
+				t.Fatalf("TestValidateDockerBuild() expected a DockerBuildError, got %v", err)
+			}
+			if buildErr.Reason != tt.wantReason || buildErr.Line != tt.wantLine {
+				t.Errorf("TestValidateDockerBuild() error mismatch - got reason %s at line %d, wanted reason %s at line %d: %v", buildErr.Reason, buildErr.Line, tt.wantReason, tt.wantLine, err)
+			}
+		})
+	}
+}
+
+func TestValidateDockerBuildSymlinks(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "dockerbuild")
+	if err != nil {
+		t.Fatalf("TestValidateDockerBuildSymlinks() unexpected error %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	projectDir := filepath.Join(tempDir, "project")
+	if err := os.Mkdir(projectDir, 0755); err != nil {
+		t.Fatalf("TestValidateDockerBuildSymlinks() unexpected error %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(tempDir, "Dockerfile"), []byte("FROM alpine\n"), 0644); err != nil {
+		t.Fatalf("TestValidateDockerBuildSymlinks() unexpected error %v", err)
+	}
+	if err := os.Symlink(filepath.Join(tempDir, "Dockerfile"), filepath.Join(projectDir, "Dockerfile")); err != nil {
+		t.Skipf("TestValidateDockerBuildSymlinks() unable to create a symbolic link: %v", err)
+	}
+
+	devObj := parser.DevfileObj{
+		Data: testingutil.GetFakeDevfileDataWithMetadataAttributes(map[string]interface{}{
+			BuildDockerfileAttribute: "Dockerfile",
+		}),
+	}
+	devObj.Ctx = devfileCtx.FakeContext(filesystem.DefaultFs{}, filepath.Join(projectDir, "devfile.yaml"))
+	err = ValidateDockerBuild(devObj)
+	if buildErr, ok := err.(*DockerBuildError); !ok || buildErr.Reason != DockerBuildOutsideProjectReason {
+		t.Errorf("TestValidateDockerBuildSymlinks() expected a DockerBuildError with the reason %s, got %v", DockerBuildOutsideProjectReason, err)
+	}
+}
@@ -1,31 +1,109 @@
 package generator
 
 import (
+	"fmt"
+	"strings"
+
 	buildv1 "github.com/openshift/api/build/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	devfilecommands "github.com/devfile/library/pkg/devfile/commands"
+	"github.com/devfile/library/pkg/devfile/dockerfile"
 	"github.com/devfile/library/pkg/devfile/parser"
 	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/devfile/library/pkg/util"
+	"github.com/pkg/errors"
 )
 
 const (
 	// DevfileSourceVolumeMount is the default directory to mount the volume in the container
-	DevfileSourceVolumeMount = "/projects"
+	DevfileSourceVolumeMount = common.DefaultProjectsRoot
 
 	// EnvProjectsRoot is the env defined for project mount in a component container when component's mountSources=true
-	EnvProjectsRoot = "PROJECTS_ROOT"
+	EnvProjectsRoot = common.EnvProjectsRoot
 
 	// EnvProjectsSrc is the env defined for path to the project source in a component container
-	EnvProjectsSrc = "PROJECT_SOURCE"
+	EnvProjectsSrc = common.EnvProjectSource
+
+	// PreStartEvent is the name of the devfile event run before the containers start
+	PreStartEvent = "preStart"
+
+	// PostStartEvent is the name of the devfile event run after the containers start
+	PostStartEvent = "postStart"
+
+	// PreStopEvent is the name of the devfile event run before the containers stop
+	PreStopEvent = "preStop"
+
+	// PostStopEvent is the name of the devfile event run after the containers stop
+	PostStopEvent = "postStop"
+
+	// SCTPEndpointProtocol is the protocol of endpoints with SCTP traffic
+	// it is not part of the endpoint protocols of the devfile schema 2.0.0, but is supported by the generator
+	SCTPEndpointProtocol v1.EndpointProtocol = "sctp"
+
+	// NetworkingV1IngressAPIVersion is the api version of the networking.k8s.io/v1 ingresses
+	NetworkingV1IngressAPIVersion = "networking.k8s.io/v1"
+
+	// ExtensionsV1Beta1IngressAPIVersion is the api version of the extensions/v1beta1 ingresses
+	ExtensionsV1Beta1IngressAPIVersion = "extensions/v1beta1"
 
 	deploymentKind       = "Deployment"
 	deploymentAPIVersion = "apps/v1"
+
+	ingressKind     = "Ingress"
+	routeKind       = "Route"
+	routeAPIVersion = "route.openshift.io/v1"
+
+	// portNameMaxLength is the maximum length of a kubernetes port name
+	portNameMaxLength = 15
+
+	// resourceNameMaxLength is the maximum length of the name of the generated kubernetes resources
+	resourceNameMaxLength = 63
+
+	// DevfilePodLabel is the label set on the pods generated by GetPodDeployments, its value is the name of the pod deployment
+	DevfilePodLabel = "devfile.io/pod"
+
+	// DefaultVolumeSize is the size of the pvc of a volume component without size
+	DefaultVolumeSize = "1Gi"
+
+	jobKind       = "Job"
+	jobAPIVersion = "batch/v1"
+
+	serviceKind = "Service"
+	pvcKind     = "PersistentVolumeClaim"
+	coreV1      = "v1"
+
+	// BuildDockerfileAttribute is the devfile metadata attribute with the path of the Dockerfile building the devfile image
+	BuildDockerfileAttribute = dockerfile.BuildDockerfileAttribute
+
+	// BuildContextAttribute is the devfile metadata attribute with the path of the build context directory
+	BuildContextAttribute = dockerfile.BuildContextAttribute
+
+	// BuildArgsAttribute is the devfile metadata attribute with the build arguments of the Dockerfile
+	BuildArgsAttribute = dockerfile.BuildArgsAttribute
+
+	// DefaultKanikoImage is the image of the kaniko executor building the Dockerfile in the build jobs
+	DefaultKanikoImage = "gcr.io/kaniko-project/executor:v1.3.0"
+
+	// DefaultGitImage is the image cloning the git source in the build jobs
+	DefaultGitImage = "alpine/git:v2.26.2"
+
+	buildConfigKind       = "BuildConfig"
+	buildConfigAPIVersion = "build.openshift.io/v1"
+	imageStreamKind       = "ImageStream"
+	imageStreamAPIVersion = "image.openshift.io/v1"
 )
 
 // GetTypeMeta gets a type meta of the specified kind and version
@@ -51,6 +129,30 @@ func GetObjectMeta(name, namespace string, labels, annotations map[string]string
 
 // GetContainers iterates through the devfile components and returns a slice of the corresponding containers
 func GetContainers(devfileObj parser.DevfileObj, options common.DevfileOptions) ([]corev1.Container, error) {
+	return GetContainersWithResources(devfileObj, ContainerResourceParams{}, options)
+}
+
+// ResourceRequirementsParams is a struct that contains the quantities of the resource requirements of a container
+type ResourceRequirementsParams struct {
+	MemoryRequest           string
+	MemoryLimit             string
+	CPURequest              string
+	CPULimit                string
+	EphemeralStorageRequest string
+	EphemeralStorageLimit   string
+}
+
+// ContainerResourceParams is a struct that contains the default resource requirements of the containers
+// Defaults applies to every container, ComponentDefaults applies to the container of the component with the given name
+// and takes precedence over Defaults. The memoryLimit set in the devfile takes precedence over both
+type ContainerResourceParams struct {
+	Defaults          ResourceRequirementsParams
+	ComponentDefaults map[string]ResourceRequirementsParams
+}
+
+// GetContainersWithResources iterates through the devfile components and returns a slice of the corresponding containers
+// with their resource requirements generated from the devfile and the default resource requirements
+func GetContainersWithResources(devfileObj parser.DevfileObj, resourceParams ContainerResourceParams, options common.DevfileOptions) ([]corev1.Container, error) {
 	var containers []corev1.Container
 	containerComponents, err := devfileObj.Data.GetDevfileContainerComponents(options)
 	if err != nil {
@@ -58,7 +160,11 @@ func GetContainers(devfileObj parser.DevfileObj, options common.DevfileOptions)
 	}
 	for _, comp := range containerComponents {
 		envVars := convertEnvs(comp.Container.Env)
-		resourceReqs := getResourceReqs(comp)
+		resourceDefaults := mergeResourceRequirementsParams(resourceParams.ComponentDefaults[comp.Name], resourceParams.Defaults)
+		resourceReqs, err := getResourceReqs(comp, resourceDefaults)
+		if err != nil {
+			return nil, err
+		}
 		ports := convertPorts(comp.Container.Endpoints)
 		containerParams := containerParams{
 			Name:         comp.Name,
@@ -90,6 +196,41 @@ func GetContainers(devfileObj parser.DevfileObj, options common.DevfileOptions)
 	return containers, nil
 }
 
+// GetK8sLikeComponentResources iterates through the kubernetes and openshift components of the devfile
+// and returns the resources defined in their manifests, either inlined or referenced by uri.
+// The labels and the namespace of the objectMeta are applied to every returned resource
+func GetK8sLikeComponentResources(devfileObj parser.DevfileObj, objectMeta metav1.ObjectMeta, options common.DevfileOptions) ([]runtime.Object, error) {
+	var resources []runtime.Object
+	components, err := devfileObj.Data.GetComponents(options)
+	if err != nil {
+		return nil, err
+	}
+	for _, comp := range components {
+		var k8sLikeComponent *v1.K8sLikeComponent
+		if comp.Kubernetes != nil {
+			k8sLikeComponent = &comp.Kubernetes.K8sLikeComponent
+		} else if comp.Openshift != nil {
+			k8sLikeComponent = &comp.Openshift.K8sLikeComponent
+		} else {
+			continue
+		}
+
+		content, err := getK8sLikeComponentContent(devfileObj.Ctx, comp.Name, *k8sLikeComponent)
+		if err != nil {
+			return nil, err
+		}
+		objects, err := decodeK8sManifest(content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode the manifest of component %s", comp.Name)
+		}
+		for _, object := range objects {
+			applyObjectMeta(object, objectMeta)
+			resources = append(resources, object)
+		}
+	}
+	return resources, nil
+}
+
 // DeploymentParams is a struct that contains the required data to create a deployment object
 type DeploymentParams struct {
 	TypeMeta          metav1.TypeMeta
@@ -168,6 +309,294 @@ func GetService(devfileObj parser.DevfileObj, serviceParams ServiceParams, optio
 	return service, nil
 }
 
+// GetServices gets a service for every container component that has endpoints to expose.
+// Each service is named after the serviceParams object meta name suffixed with the component name
+func GetServices(devfileObj parser.DevfileObj, serviceParams ServiceParams, options common.DevfileOptions) ([]corev1.Service, error) {
+
+	containerComponents, err := devfileObj.Data.GetDevfileContainerComponents(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []corev1.Service
+	for _, comp := range containerComponents {
+		serviceSpec := getServiceSpecFromEndpoints(comp.Container.Endpoints, serviceParams.SelectorLabels)
+		if len(serviceSpec.Ports) == 0 {
+			continue
+		}
+
+		objectMeta := *serviceParams.ObjectMeta.DeepCopy()
+		objectMeta.Name = getResourceName(serviceParams.ObjectMeta.Name, comp.Name)
+		services = append(services, corev1.Service{
+			TypeMeta:   serviceParams.TypeMeta,
+			ObjectMeta: objectMeta,
+			Spec:       *serviceSpec,
+		})
+	}
+
+	return services, nil
+}
+
+// PodDeploymentParams is a struct that contains the required data to create the deployments, services and pvcs of a devfile
+type PodDeploymentParams struct {
+	ObjectMeta        metav1.ObjectMeta
+	PodSelectorLabels map[string]string
+	ResourceParams    ContainerResourceParams
+	// VolumeAccessMode is the access mode of the volume pvcs, ReadWriteOnce by default for volumes mounted by a single pod
+	// and ReadWriteMany for volumes shared across pods. Requesting ReadWriteOnce for a volume shared across pods is an error
+	VolumeAccessMode corev1.PersistentVolumeAccessMode
+}
+
+// PodDeployment is a deployment running the containers of some devfile components, with the service exposing their endpoints
+type PodDeployment struct {
+	ComponentNames []string
+	Deployment     *appsv1.Deployment
+	// Service is nil if the components have no endpoints to expose
+	Service *corev1.Service
+}
+
+// GetPodDeployments iterates through the devfile container components and returns the deployments running them,
+// along with the pvcs of the volumes they mount. The components with dedicatedPod set to true run in their own deployment
+// named after the objectMeta name suffixed with the component name, the other components run together in a deployment
+// named after the objectMeta name. The exec commands of the preStart event run as init containers of the deployment of their
+// component, if their component is excluded by the options, error out
+func GetPodDeployments(devfileObj parser.DevfileObj, deploymentParams PodDeploymentParams, options common.DevfileOptions) ([]PodDeployment, []corev1.PersistentVolumeClaim, error) {
+	containerComponents, err := devfileObj.Data.GetDevfileContainerComponents(options)
+	if err != nil {
+		return nil, nil, err
+	}
+	containers, err := GetContainersWithResources(devfileObj, deploymentParams.ResourceParams, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	volumeComponents, err := devfileObj.Data.GetDevfileVolumeComponents(common.DevfileOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	initContainers, err := getEventContainers(devfileObj, deploymentParams.ResourceParams, PreStartEvent, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the preStart commands must run before their component, which must not be excluded by the options
+	for _, initContainer := range initContainers {
+		found := false
+		for _, comp := range containerComponents {
+			found = found || comp.Name == initContainer.component.Name
+		}
+		if !found {
+			return nil, nil, fmt.Errorf("the component %s of the preStart init container %s is excluded by the filter options", initContainer.component.Name, initContainer.name)
+		}
+	}
+	if err = AddLifecycleHooks(devfileObj, containers); err != nil {
+		return nil, nil, err
+	}
+
+	pods := getDevfilePods(deploymentParams.ObjectMeta.Name, containerComponents, containers)
+
+	var podDeployments []PodDeployment
+	volumePods := make(map[string][]string)
+	for _, pod := range pods {
+		var volumes []corev1.Volume
+		for i, comp := range pod.components {
+			addVolumeMounts(&pod.containers[i], comp.Container.VolumeMounts)
+			for _, volumeMount := range comp.Container.VolumeMounts {
+				if util.In(volumePods[volumeMount.Name], pod.name) {
+					continue
+				}
+				volumePods[volumeMount.Name] = append(volumePods[volumeMount.Name], pod.name)
+				volumes = append(volumes, getPVCVolume(volumeMount.Name, getResourceName(deploymentParams.ObjectMeta.Name, volumeMount.Name)))
+			}
+		}
+
+		var podInitContainers []corev1.Container
+		for _, initContainer := range initContainers {
+			for _, comp := range pod.components {
+				if comp.Name == initContainer.component.Name {
+					addVolumeMounts(&initContainer.container, comp.Container.VolumeMounts)
+					podInitContainers = append(podInitContainers, initContainer.container)
+				}
+			}
+		}
+
+		selectorLabels := make(map[string]string)
+		for key, value := range deploymentParams.PodSelectorLabels {
+			selectorLabels[key] = value
+		}
+		selectorLabels[DevfilePodLabel] = pod.name
+
+		objectMeta := *deploymentParams.ObjectMeta.DeepCopy()
+		objectMeta.Name = pod.name
+		if objectMeta.Labels == nil {
+			objectMeta.Labels = make(map[string]string)
+		}
+		for key, value := range selectorLabels {
+			objectMeta.Labels[key] = value
+		}
+
+		podDeployment := PodDeployment{
+			Deployment: GetDeployment(DeploymentParams{
+				TypeMeta:          GetTypeMeta(deploymentKind, deploymentAPIVersion),
+				ObjectMeta:        objectMeta,
+				InitContainers:    podInitContainers,
+				Containers:        pod.containers,
+				Volumes:           volumes,
+				PodSelectorLabels: selectorLabels,
+			}),
+		}
+
+		var endpoints []v1.Endpoint
+		for _, comp := range pod.components {
+			podDeployment.ComponentNames = append(podDeployment.ComponentNames, comp.Name)
+			endpoints = append(endpoints, comp.Container.Endpoints...)
+		}
+		if serviceSpec := getServiceSpecFromEndpoints(endpoints, selectorLabels); len(serviceSpec.Ports) > 0 {
+			podDeployment.Service = &corev1.Service{
+				TypeMeta:   GetTypeMeta(serviceKind, coreV1),
+				ObjectMeta: *objectMeta.DeepCopy(),
+				Spec:       *serviceSpec,
+			}
+		}
+		podDeployments = append(podDeployments, podDeployment)
+	}
+
+	pvcs, err := getVolumePVCs(deploymentParams.ObjectMeta, volumeComponents, volumePods, deploymentParams.VolumeAccessMode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return podDeployments, pvcs, nil
+}
+
+// GetInitContainers returns the init containers running the exec commands of the devfile preStart event, in order.
+// Each init container runs in the container of the command component, the composite commands are expanded
+func GetInitContainers(devfileObj parser.DevfileObj, resourceParams ContainerResourceParams) ([]corev1.Container, error) {
+	eventContainers, err := getEventContainers(devfileObj, resourceParams, PreStartEvent, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var initContainers []corev1.Container
+	for _, eventContainer := range eventContainers {
+		initContainers = append(initContainers, eventContainer.container)
+	}
+	return initContainers, nil
+}
+
+// AddLifecycleHooks adds the exec commands of the devfile postStart and preStop events to the lifecycle hooks of the containers
+// running their component, the composite commands are expanded and the commands of a container run in order
+func AddLifecycleHooks(devfileObj parser.DevfileObj, containers []corev1.Container) error {
+	events, err := devfileObj.Data.GetEvents(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+	commands, err := devfileObj.Data.GetCommands(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range []struct {
+		name       string
+		commandIDs []string
+	}{
+		{name: PostStartEvent, commandIDs: events.PostStart},
+		{name: PreStopEvent, commandIDs: events.PreStop},
+	} {
+		eventCommands, err := getEventCommands(commands, event.name, event.commandIDs)
+		if err != nil {
+			return err
+		}
+
+		scripts := make(map[string][]string)
+		for _, command := range eventCommands {
+			if command.Exec == nil {
+				continue
+			}
+			found := false
+			for _, container := range containers {
+				if container.Name == command.Exec.Component {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("the container of component %s of the %s command %s is not found", command.Exec.Component, event.name, command.Id)
+			}
+			scripts[command.Exec.Component] = append(scripts[command.Exec.Component], fmt.Sprintf("(%s)", devfilecommands.GetExecScript(command.Exec.CommandLine, command.Exec.WorkingDir, command.Exec.Env)))
+		}
+
+		for i := range containers {
+			if len(scripts[containers[i].Name]) == 0 {
+				continue
+			}
+			handler := &corev1.Handler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"/bin/sh", "-c", strings.Join(scripts[containers[i].Name], " && ")},
+				},
+			}
+			if containers[i].Lifecycle == nil {
+				containers[i].Lifecycle = &corev1.Lifecycle{}
+			}
+			if event.name == PostStartEvent {
+				containers[i].Lifecycle.PostStart = handler
+			} else {
+				containers[i].Lifecycle.PreStop = handler
+			}
+		}
+	}
+
+	return nil
+}
+
+// EventJobParams is a struct that contains the required data to create the jobs of the devfile events
+type EventJobParams struct {
+	ObjectMeta     metav1.ObjectMeta
+	ResourceParams ContainerResourceParams
+}
+
+// EventJobs is a struct that contains the jobs of the devfile events
+type EventJobs struct {
+	PreStart  []batchv1.Job
+	PostStart []batchv1.Job
+	PreStop   []batchv1.Job
+	PostStop  []batchv1.Job
+}
+
+// GetEventJobs returns the jobs running the commands of the devfile events that are not run by init containers or lifecycle hooks:
+// the apply commands of every event, and the exec commands of the postStop event. Each job is named after the objectMeta name
+// suffixed with the event and the command id, an apply command runs the container of its component as defined in the devfile.
+// An apply command of a kubernetes or openshift component runs the jobs defined in the manifest of the component
+func GetEventJobs(devfileObj parser.DevfileObj, jobParams EventJobParams) (EventJobs, error) {
+	var eventJobs EventJobs
+	for _, event := range []struct {
+		name string
+		jobs *[]batchv1.Job
+	}{
+		{name: PreStartEvent, jobs: &eventJobs.PreStart},
+		{name: PostStartEvent, jobs: &eventJobs.PostStart},
+		{name: PreStopEvent, jobs: &eventJobs.PreStop},
+		{name: PostStopEvent, jobs: &eventJobs.PostStop},
+	} {
+		eventContainers, err := getEventContainers(devfileObj, jobParams.ResourceParams, event.name, true)
+		if err != nil {
+			return EventJobs{}, err
+		}
+		for _, eventContainer := range eventContainers {
+			objectMeta := *jobParams.ObjectMeta.DeepCopy()
+			objectMeta.Name = getResourceName(jobParams.ObjectMeta.Name, eventContainer.name)
+			if eventContainer.component.Container == nil {
+				jobs, err := getManifestJobs(devfileObj.Ctx, objectMeta, eventContainer)
+				if err != nil {
+					return EventJobs{}, err
+				}
+				*event.jobs = append(*event.jobs, jobs...)
+				continue
+			}
+			*event.jobs = append(*event.jobs, *getJob(objectMeta, jobParams.ObjectMeta.Name, eventContainer))
+		}
+	}
+	return eventJobs, nil
+}
+
 // IngressParams is a struct that contains the required data to create an ingress object
 type IngressParams struct {
 	TypeMeta          metav1.TypeMeta
@@ -175,7 +604,7 @@ type IngressParams struct {
 	IngressSpecParams IngressSpecParams
 }
 
-// GetIngress gets an ingress
+// GetIngress gets an extensions/v1beta1 ingress
 func GetIngress(ingressParams IngressParams) *extensionsv1.Ingress {
 
 	ingressSpec := getIngressSpec(ingressParams.IngressSpecParams)
@@ -189,6 +618,210 @@ func GetIngress(ingressParams IngressParams) *extensionsv1.Ingress {
 	return ingress
 }
 
+// GetNetworkingV1Ingress gets a networking.k8s.io/v1 ingress
+func GetNetworkingV1Ingress(ingressParams IngressParams) *networkingv1.Ingress {
+
+	ingressSpec := getNetworkingV1IngressSpec(ingressParams.IngressSpecParams)
+
+	ingress := &networkingv1.Ingress{
+		TypeMeta:   ingressParams.TypeMeta,
+		ObjectMeta: ingressParams.ObjectMeta,
+		Spec:       *ingressSpec,
+	}
+
+	return ingress
+}
+
+// GetIngressForAPIVersion gets an ingress of the api version, networking.k8s.io/v1 if the api version is empty.
+// The type meta of the ingress is set to the api version
+func GetIngressForAPIVersion(ingressParams IngressParams, apiVersion string) (Ingress, error) {
+	if apiVersion == "" {
+		apiVersion = NetworkingV1IngressAPIVersion
+	}
+	ingressParams.TypeMeta = GetTypeMeta(ingressKind, apiVersion)
+
+	switch apiVersion {
+	case NetworkingV1IngressAPIVersion:
+		return Ingress{NetworkingV1: GetNetworkingV1Ingress(ingressParams)}, nil
+	case ExtensionsV1Beta1IngressAPIVersion:
+		return Ingress{ExtensionsV1Beta1: GetIngress(ingressParams)}, nil
+	default:
+		return Ingress{}, fmt.Errorf("unsupported ingress api version %s", apiVersion)
+	}
+}
+
+// ServerResourcesDiscovery is the part of the client-go discovery interface used to discover the ingress api version
+type ServerResourcesDiscovery interface {
+	ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error)
+}
+
+// GetIngressAPIVersion discovers the ingress api version served by the cluster.
+// networking.k8s.io/v1 is returned if the cluster serves its ingresses, extensions/v1beta1 otherwise.
+// An error is returned if the cluster serves the ingresses of neither version
+func GetIngressAPIVersion(discovery ServerResourcesDiscovery) (string, error) {
+	for _, apiVersion := range []string{NetworkingV1IngressAPIVersion, ExtensionsV1Beta1IngressAPIVersion} {
+		served, err := servesIngresses(discovery, apiVersion)
+		if err != nil {
+			return "", err
+		}
+		if served {
+			return apiVersion, nil
+		}
+	}
+	return "", fmt.Errorf("the cluster serves neither %s nor %s ingresses", NetworkingV1IngressAPIVersion, ExtensionsV1Beta1IngressAPIVersion)
+}
+
+// servesIngresses checks if the cluster serves the ingresses of the api version
+func servesIngresses(discovery ServerResourcesDiscovery, apiVersion string) (bool, error) {
+	resources, err := discovery.ServerResourcesForGroupVersion(apiVersion)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to discover the resources of %s", apiVersion)
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Kind == ingressKind {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Ingress holds an ingress of one of the supported ingress api versions, only one of the fields is set
+type Ingress struct {
+	NetworkingV1      *networkingv1.Ingress
+	ExtensionsV1Beta1 *extensionsv1.Ingress
+}
+
+// EndpointIngressParams is a struct that contains the required data to create ingresses for the public endpoints
+// ObjectMeta is applied to every ingress, its name is used as the prefix of the ingress names
+// ServiceName is the name of the service the ingresses target, if empty the service of the endpoint
+// component created by GetServices is targeted
+// HostTemplate is a text/template for the host of the ingresses, e.g. {{.Endpoint}}.{{.Namespace}}.example.com
+// the fields Name, Namespace, Component, Endpoint and Port can be used in the template
+// TLSSecretName is the TLS secret of the ingresses of secure endpoints
+// PathType and IngressClassName are set on every ingress, see IngressSpecParams
+// APIVersion is the ingress api version, networking.k8s.io/v1 by default or extensions/v1beta1 for older clusters,
+// see GetIngressAPIVersion to discover the version served by a cluster
+type EndpointIngressParams struct {
+	ObjectMeta       metav1.ObjectMeta
+	ServiceName      string
+	HostTemplate     string
+	TLSSecretName    string
+	PathType         networkingv1.PathType
+	IngressClassName string
+	APIVersion       string
+}
+
+// GetEndpointIngresses creates an ingress for every container endpoint with the exposure public.
+// The path of the endpoint is used as the ingress path, and secure endpoints get a TLS section
+func GetEndpointIngresses(devfileObj parser.DevfileObj, ingressParams EndpointIngressParams, options common.DevfileOptions) ([]Ingress, error) {
+	endpoints, err := getPublicEndpoints(devfileObj, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var ingresses []Ingress
+	for _, endpoint := range endpoints {
+		host, err := getHost(ingressParams.HostTemplate, hostTemplateData{
+			Name:      ingressParams.ObjectMeta.Name,
+			Namespace: ingressParams.ObjectMeta.Namespace,
+			Component: endpoint.ComponentName,
+			Endpoint:  endpoint.Endpoint.Name,
+			Port:      endpoint.Endpoint.TargetPort,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		serviceName := ingressParams.ServiceName
+		if serviceName == "" {
+			serviceName = getResourceName(ingressParams.ObjectMeta.Name, endpoint.ComponentName)
+		}
+		ingressSpecParams := IngressSpecParams{
+			ServiceName:      serviceName,
+			IngressDomain:    host,
+			PortNumber:       intstr.FromInt(endpoint.Endpoint.TargetPort),
+			Path:             endpoint.Endpoint.Path,
+			PathType:         ingressParams.PathType,
+			IngressClassName: ingressParams.IngressClassName,
+		}
+		if isSecureEndpoint(endpoint.Endpoint) {
+			ingressSpecParams.TLSSecretName = ingressParams.TLSSecretName
+		}
+
+		objectMeta := *ingressParams.ObjectMeta.DeepCopy()
+		objectMeta.Name = getResourceName(ingressParams.ObjectMeta.Name, endpoint.Endpoint.Name)
+
+		ingress, err := GetIngressForAPIVersion(IngressParams{
+			ObjectMeta:        objectMeta,
+			IngressSpecParams: ingressSpecParams,
+		}, ingressParams.APIVersion)
+		if err != nil {
+			return nil, err
+		}
+		ingresses = append(ingresses, ingress)
+	}
+	return ingresses, nil
+}
+
+// EndpointRouteParams is a struct that contains the required data to create routes for the public endpoints
+// ObjectMeta is applied to every route, its name is used as the prefix of the route names
+// ServiceName is the name of the service the routes target, if empty the service of the endpoint
+// component created by GetServices is targeted
+// HostTemplate is an optional text/template for the host of the routes, see EndpointIngressParams
+type EndpointRouteParams struct {
+	ObjectMeta   metav1.ObjectMeta
+	ServiceName  string
+	HostTemplate string
+}
+
+// GetEndpointRoutes creates a route for every container endpoint with the exposure public.
+// The path of the endpoint is used as the route path, and secure endpoints get an edge terminated route
+func GetEndpointRoutes(devfileObj parser.DevfileObj, routeParams EndpointRouteParams, options common.DevfileOptions) ([]routev1.Route, error) {
+	endpoints, err := getPublicEndpoints(devfileObj, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var routes []routev1.Route
+	for _, endpoint := range endpoints {
+		host, err := getHost(routeParams.HostTemplate, hostTemplateData{
+			Name:      routeParams.ObjectMeta.Name,
+			Namespace: routeParams.ObjectMeta.Namespace,
+			Component: endpoint.ComponentName,
+			Endpoint:  endpoint.Endpoint.Name,
+			Port:      endpoint.Endpoint.TargetPort,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		serviceName := routeParams.ServiceName
+		if serviceName == "" {
+			serviceName = getResourceName(routeParams.ObjectMeta.Name, endpoint.ComponentName)
+		}
+
+		objectMeta := *routeParams.ObjectMeta.DeepCopy()
+		objectMeta.Name = getResourceName(routeParams.ObjectMeta.Name, endpoint.Endpoint.Name)
+
+		route := GetRoute(RouteParams{
+			TypeMeta:   GetTypeMeta(routeKind, routeAPIVersion),
+			ObjectMeta: objectMeta,
+			RouteSpecParams: RouteSpecParams{
+				ServiceName: serviceName,
+				PortNumber:  intstr.FromInt(endpoint.Endpoint.TargetPort),
+				Path:        endpoint.Endpoint.Path,
+				Secure:      isSecureEndpoint(endpoint.Endpoint),
+			},
+		})
+		route.Spec.Host = host
+		routes = append(routes, *route)
+	}
+	return routes, nil
+}
+
 // RouteParams is a struct that contains the required data to create a route object
 type RouteParams struct {
 	TypeMeta        metav1.TypeMeta
@@ -284,3 +917,114 @@ func GetImageStream(imageStreamParams ImageStreamParams) imagev1.ImageStream {
 	}
 	return imageStream
 }
+
+// DockerBuildInfo is the build information of the devfile metadata
+type DockerBuildInfo struct {
+	// DockerfilePath is the path of the Dockerfile relative to the devfile directory
+	DockerfilePath string
+	// BuildContext is the path of the build context directory relative to the devfile directory, "." for the devfile directory
+	BuildContext string
+	BuildArgs    []corev1.EnvVar
+}
+
+// GetDockerBuildInfo returns the build information of the devfile metadata
+func GetDockerBuildInfo(devfileObj parser.DevfileObj) (DockerBuildInfo, error) {
+	var buildInfo DockerBuildInfo
+	var err error
+	metadataAttributes := devfileObj.Data.GetMetadata().Attributes
+	buildInfo.DockerfilePath, buildInfo.BuildContext, err = dockerfile.GetDockerBuildPaths(metadataAttributes)
+	if err != nil {
+		return buildInfo, err
+	}
+	buildInfo.BuildArgs, err = getBuildArgs(metadataAttributes)
+	return buildInfo, err
+}
+
+// DockerBuildSource is the git source of the project built from the Dockerfile of the devfile metadata
+type DockerBuildSource struct {
+	GitURL string
+	GitRef string
+}
+
+// GetDockerBuildSource returns the git source of the project with the given name, or of the first git project if the name is empty.
+// The devfile is expected at the root of the git source, the paths of the build information are relative to it
+func GetDockerBuildSource(devfileObj parser.DevfileObj, projectName string) (DockerBuildSource, error) {
+	var buildSource DockerBuildSource
+	var err error
+	buildSource.GitURL, buildSource.GitRef, err = getProjectGitSource(devfileObj, projectName)
+	return buildSource, err
+}
+
+// DockerBuildParams is a struct that contains the required data to create the resources building the devfile Dockerfile
+type DockerBuildParams struct {
+	ObjectMeta metav1.ObjectMeta
+	// ProjectName is the name of the project built, the first git project if empty
+	ProjectName string
+}
+
+// GetDockerBuildConfig returns the build config building the Dockerfile of the devfile metadata from the git source of the project,
+// and the image stream the image is pushed to, both named after the objectMeta name. The Dockerfile must be in the build context
+func GetDockerBuildConfig(devfileObj parser.DevfileObj, buildParams DockerBuildParams) (*buildv1.BuildConfig, imagev1.ImageStream, error) {
+	buildInfo, err := GetDockerBuildInfo(devfileObj)
+	if err != nil {
+		return nil, imagev1.ImageStream{}, err
+	}
+	buildSource, err := GetDockerBuildSource(devfileObj, buildParams.ProjectName)
+	if err != nil {
+		return nil, imagev1.ImageStream{}, err
+	}
+	dockerfilePath, err := getContextDockerfilePath(buildInfo)
+	if err != nil {
+		return nil, imagev1.ImageStream{}, err
+	}
+	contextDir := buildInfo.BuildContext
+	if contextDir == "." {
+		contextDir = ""
+	}
+
+	buildStrategy := GetDockerBuildStrategy(dockerfilePath, nil)
+	buildStrategy.DockerStrategy.BuildArgs = buildInfo.BuildArgs
+	buildConfig := GetBuildConfig(BuildConfigParams{
+		TypeMeta:   GetTypeMeta(buildConfigKind, buildConfigAPIVersion),
+		ObjectMeta: buildParams.ObjectMeta,
+		BuildConfigSpecParams: BuildConfigSpecParams{
+			ImageStreamTagName: buildParams.ObjectMeta.Name,
+			GitURL:             buildSource.GitURL,
+			GitRef:             buildSource.GitRef,
+			ContextDir:         contextDir,
+			BuildStrategy:      buildStrategy,
+		},
+	})
+	imageStream := GetImageStream(ImageStreamParams{
+		TypeMeta:   GetTypeMeta(imageStreamKind, imageStreamAPIVersion),
+		ObjectMeta: buildParams.ObjectMeta,
+	})
+	return buildConfig, imageStream, nil
+}
+
+// KanikoJobParams is a struct that contains the required data to create the job building the devfile Dockerfile with kaniko
+type KanikoJobParams struct {
+	DockerBuildParams
+	// Image is the image the built image is pushed to, the image is not pushed if empty
+	Image string
+	// PushSecretName is the name of the docker config secret used to push the image, optional
+	PushSecretName string
+	// KanikoImage is the image of the kaniko executor, DefaultKanikoImage if empty
+	KanikoImage string
+	// GitImage is the image cloning the git source, DefaultGitImage if empty
+	GitImage string
+}
+
+// GetKanikoBuildJob returns the job building the Dockerfile of the devfile metadata with kaniko from the git source of the project,
+// an init container clones the git source in a volume shared with the kaniko container
+func GetKanikoBuildJob(devfileObj parser.DevfileObj, jobParams KanikoJobParams) (*batchv1.Job, error) {
+	buildInfo, err := GetDockerBuildInfo(devfileObj)
+	if err != nil {
+		return nil, err
+	}
+	buildSource, err := GetDockerBuildSource(devfileObj, jobParams.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	return getKanikoJob(jobParams, buildInfo, buildSource), nil
+}
@@ -1,16 +1,28 @@
 package generator
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
 	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/api/pkg/attributes"
+	devfilepkg "github.com/devfile/api/pkg/devfile"
 	"github.com/devfile/library/pkg/devfile/parser"
+	devfileCtx "github.com/devfile/library/pkg/devfile/parser/context"
+	v2 "github.com/devfile/library/pkg/devfile/parser/data/v2"
 	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 	"github.com/devfile/library/pkg/testingutil"
+	"github.com/devfile/library/pkg/testingutil/filesystem"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var fakeResources corev1.ResourceRequirements
@@ -202,3 +214,1551 @@ func TestGetContainers(t *testing.T) {
 	}
 
 }
+
+func TestGetK8sLikeComponentResources(t *testing.T) {
+
+	deploymentManifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy-sample
+  labels:
+    app: sample
+`
+	serviceManifest := `apiVersion: v1
+kind: Service
+metadata:
+  name: service-sample
+  namespace: other
+`
+	multiDocManifest := deploymentManifest + "---\n" + serviceManifest + "---\n"
+
+	fs := filesystem.NewFakeFs()
+	err := fs.WriteFile("/devfile/manifests/deploy.yaml", []byte(multiDocManifest), 0644)
+	if err != nil {
+		t.Errorf("TestGetK8sLikeComponentResources unexpected error: %v", err)
+		return
+	}
+
+	// a manifest outside of the devfile directory, which must not be read
+	err = fs.WriteFile("/etc/manifests/deploy.yaml", []byte(deploymentManifest), 0644)
+	if err != nil {
+		t.Errorf("TestGetK8sLikeComponentResources unexpected error: %v", err)
+		return
+	}
+
+	objectMeta := GetObjectMeta("", "testns", map[string]string{"component": "testcomponent"}, nil)
+
+	tests := []struct {
+		name          string
+		components    []v1.Component
+		filterOptions common.DevfileOptions
+		wantNames     []string
+		wantLabels    []map[string]string
+		wantErr       bool
+	}{
+		{
+			name: "Case 1: Inlined kubernetes component",
+			components: []v1.Component{
+				testingutil.GetFakeContainerComponent("runtime"),
+				{
+					Name: "deploy",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{
+							K8sLikeComponent: v1.K8sLikeComponent{
+								K8sLikeComponentLocation: v1.K8sLikeComponentLocation{
+									Inlined: deploymentManifest,
+								},
+							},
+						},
+					},
+				},
+			},
+			wantNames: []string{"deploy-sample"},
+			wantLabels: []map[string]string{
+				{"app": "sample", "component": "testcomponent"},
+			},
+		},
+		{
+			name: "Case 2: Openshift component with a relative uri",
+			components: []v1.Component{
+				{
+					Name: "deploy",
+					ComponentUnion: v1.ComponentUnion{
+						Openshift: &v1.OpenshiftComponent{
+							K8sLikeComponent: v1.K8sLikeComponent{
+								K8sLikeComponentLocation: v1.K8sLikeComponentLocation{
+									Uri: "manifests/deploy.yaml",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantNames: []string{"deploy-sample", "service-sample"},
+			wantLabels: []map[string]string{
+				{"app": "sample", "component": "testcomponent"},
+				{"component": "testcomponent"},
+			},
+		},
+		{
+			name: "Case 3: Filter kubernetes components",
+			components: []v1.Component{
+				{
+					Name: "deploy",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{
+							K8sLikeComponent: v1.K8sLikeComponent{
+								K8sLikeComponentLocation: v1.K8sLikeComponentLocation{
+									Inlined: deploymentManifest,
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: "service",
+					Attributes: attributes.Attributes{}.FromStringMap(map[string]string{
+						"firstString": "firstStringValue",
+					}),
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{
+							K8sLikeComponent: v1.K8sLikeComponent{
+								K8sLikeComponentLocation: v1.K8sLikeComponentLocation{
+									Inlined: serviceManifest,
+								},
+							},
+						},
+					},
+				},
+			},
+			filterOptions: common.DevfileOptions{
+				Filter: map[string]interface{}{
+					"firstString": "firstStringValue",
+				},
+			},
+			wantNames: []string{"service-sample"},
+			wantLabels: []map[string]string{
+				{"component": "testcomponent"},
+			},
+		},
+		{
+			name: "Case 4: Missing manifest file",
+			components: []v1.Component{
+				{
+					Name: "deploy",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{
+							K8sLikeComponent: v1.K8sLikeComponent{
+								K8sLikeComponentLocation: v1.K8sLikeComponentLocation{
+									Uri: "manifests/missing.yaml",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 5: Manifest without a kind",
+			components: []v1.Component{
+				{
+					Name: "deploy",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{
+							K8sLikeComponent: v1.K8sLikeComponent{
+								K8sLikeComponentLocation: v1.K8sLikeComponentLocation{
+									Inlined: "metadata:\n  name: invalid\n",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 6: Absolute manifest uri",
+			components: []v1.Component{
+				{
+					Name: "deploy",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{
+							K8sLikeComponent: v1.K8sLikeComponent{
+								K8sLikeComponentLocation: v1.K8sLikeComponentLocation{
+									Uri: "/etc/manifests/deploy.yaml",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 7: Manifest uri outside of the devfile directory",
+			components: []v1.Component{
+				{
+					Name: "deploy",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{
+							K8sLikeComponent: v1.K8sLikeComponent{
+								K8sLikeComponentLocation: v1.K8sLikeComponentLocation{
+									Uri: "manifests/../../etc/manifests/deploy.yaml",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devObj := parser.DevfileObj{
+				Ctx: devfileCtx.FakeContext(fs, "/devfile/devfile.yaml"),
+				Data: &testingutil.TestDevfileData{
+					Components: tt.components,
+				},
+			}
+
+			resources, err := GetK8sLikeComponentResources(devObj, objectMeta, tt.filterOptions)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestGetK8sLikeComponentResources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(resources) != len(tt.wantNames) {
+				t.Errorf("TestGetK8sLikeComponentResources error: resources length mismatch - got: %d, wanted: %d", len(resources), len(tt.wantNames))
+				return
+			}
+			for i, resource := range resources {
+				object, ok := resource.(metav1.Object)
+				if !ok {
+					t.Errorf("TestGetK8sLikeComponentResources error: resource %d has no object meta", i)
+					continue
+				}
+				if object.GetName() != tt.wantNames[i] {
+					t.Errorf("TestGetK8sLikeComponentResources error: Name mismatch - got: %s, wanted: %s", object.GetName(), tt.wantNames[i])
+				}
+				if object.GetNamespace() != objectMeta.Namespace {
+					t.Errorf("TestGetK8sLikeComponentResources error: Namespace mismatch - got: %s, wanted: %s", object.GetNamespace(), objectMeta.Namespace)
+				}
+				if !reflect.DeepEqual(object.GetLabels(), tt.wantLabels[i]) {
+					t.Errorf("TestGetK8sLikeComponentResources error: Labels mismatch - got: %v, wanted: %v", object.GetLabels(), tt.wantLabels[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetServices(t *testing.T) {
+
+	tests := []struct {
+		name                string
+		containerComponents []v1.Component
+		wantServiceNames    []string
+		wantPortNames       [][]string
+	}{
+		{
+			name: "Case 1: One service per component with endpoints",
+			containerComponents: []v1.Component{
+				{
+					Name: "runtime",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{
+							Endpoints: []v1.Endpoint{
+								{
+									Name:       "http",
+									TargetPort: 8080,
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: "tools",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{},
+					},
+				},
+				{
+					Name: "db",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{
+							Endpoints: []v1.Endpoint{
+								{
+									Name:       "postgres",
+									TargetPort: 5432,
+									Protocol:   v1.TCPEndpointProtocol,
+									Exposure:   v1.InternalEndpointExposure,
+								},
+								{
+									Name:       "debug",
+									TargetPort: 5858,
+									Exposure:   v1.NoneEndpointExposure,
+								},
+							},
+						},
+					},
+				},
+			},
+			wantServiceNames: []string{"myapp-runtime", "myapp-db"},
+			wantPortNames:    [][]string{{"http"}, {"postgres"}},
+		},
+		{
+			name: "Case 2: Only endpoints with exposure none",
+			containerComponents: []v1.Component{
+				{
+					Name: "runtime",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{
+							Endpoints: []v1.Endpoint{
+								{
+									Name:       "debug",
+									TargetPort: 5858,
+									Exposure:   v1.NoneEndpointExposure,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devObj := parser.DevfileObj{
+				Data: &testingutil.TestDevfileData{
+					Components: tt.containerComponents,
+				},
+			}
+			serviceParams := ServiceParams{
+				ObjectMeta:     GetObjectMeta("myapp", "testns", nil, nil),
+				SelectorLabels: map[string]string{"component": "myapp"},
+			}
+
+			services, err := GetServices(devObj, serviceParams, common.DevfileOptions{})
+			if err != nil {
+				t.Errorf("TestGetServices unexpected error: %v", err)
+				return
+			}
+
+			if len(services) != len(tt.wantServiceNames) {
+				t.Errorf("TestGetServices error: services length mismatch - got: %d, wanted: %d", len(services), len(tt.wantServiceNames))
+				return
+			}
+			for i, service := range services {
+				if service.Name != tt.wantServiceNames[i] {
+					t.Errorf("TestGetServices error: Name mismatch - got: %s, wanted: %s", service.Name, tt.wantServiceNames[i])
+				}
+				if service.Namespace != serviceParams.ObjectMeta.Namespace {
+					t.Errorf("TestGetServices error: Namespace mismatch - got: %s, wanted: %s", service.Namespace, serviceParams.ObjectMeta.Namespace)
+				}
+				var portNames []string
+				for _, port := range service.Spec.Ports {
+					portNames = append(portNames, port.Name)
+				}
+				if !reflect.DeepEqual(portNames, tt.wantPortNames[i]) {
+					t.Errorf("TestGetServices error: Port names mismatch - got: %v, wanted: %v", portNames, tt.wantPortNames[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetPodDeployments(t *testing.T) {
+
+	mountSources := false
+	getContainerComponent := func(name string, dedicatedPod bool, volumeMounts []v1.VolumeMount, endpoints []v1.Endpoint) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{
+						Image:        "image",
+						MountSources: &mountSources,
+						DedicatedPod: dedicatedPod,
+						VolumeMounts: volumeMounts,
+					},
+					Endpoints: endpoints,
+				},
+			},
+		}
+	}
+	endpoints := []v1.Endpoint{
+		{
+			Name:       "http",
+			TargetPort: 8080,
+		},
+	}
+
+	tests := []struct {
+		name            string
+		components      []v1.Component
+		accessMode      corev1.PersistentVolumeAccessMode
+		wantDeployments []string
+		wantComponents  [][]string
+		wantServices    []string
+		wantVolumes     [][]string
+		wantPVCs        []string
+		wantAccessModes []corev1.PersistentVolumeAccessMode
+		wantErr         bool
+	}{
+		{
+			name: "Case 1: All the components in one pod",
+			components: []v1.Component{
+				getContainerComponent("runtime", false, []v1.VolumeMount{testingutil.GetFakeVolumeMount("data", "")}, endpoints),
+				getContainerComponent("tools", false, []v1.VolumeMount{testingutil.GetFakeVolumeMount("data", "/tmp/data")}, nil),
+				testingutil.GetFakeVolumeComponent("data", "2Gi"),
+			},
+			wantDeployments: []string{"myapp"},
+			wantComponents:  [][]string{{"runtime", "tools"}},
+			wantServices:    []string{"myapp"},
+			wantVolumes:     [][]string{{"data"}},
+			wantPVCs:        []string{"myapp-data"},
+			wantAccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		},
+		{
+			name: "Case 2: Dedicated pod sharing a volume",
+			components: []v1.Component{
+				getContainerComponent("runtime", false, []v1.VolumeMount{testingutil.GetFakeVolumeMount("data", "")}, nil),
+				getContainerComponent("db", true, []v1.VolumeMount{testingutil.GetFakeVolumeMount("data", ""), testingutil.GetFakeVolumeMount("dbdata", "")}, endpoints),
+				testingutil.GetFakeVolumeComponent("data", ""),
+				testingutil.GetFakeVolumeComponent("dbdata", "1Gi"),
+				testingutil.GetFakeVolumeComponent("unused", "1Gi"),
+			},
+			wantDeployments: []string{"myapp", "myapp-db"},
+			wantComponents:  [][]string{{"runtime"}, {"db"}},
+			wantServices:    []string{"", "myapp-db"},
+			wantVolumes:     [][]string{{"data"}, {"data", "dbdata"}},
+			wantPVCs:        []string{"myapp-data", "myapp-dbdata"},
+			wantAccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany, corev1.ReadWriteOnce},
+		},
+		{
+			name: "Case 3: Only dedicated pods",
+			components: []v1.Component{
+				getContainerComponent("runtime", true, nil, endpoints),
+				getContainerComponent("db", true, nil, nil),
+			},
+			wantDeployments: []string{"myapp-runtime", "myapp-db"},
+			wantComponents:  [][]string{{"runtime"}, {"db"}},
+			wantServices:    []string{"myapp-runtime", ""},
+			wantVolumes:     [][]string{nil, nil},
+		},
+		{
+			name: "Case 4: ReadWriteOnce requested for a volume shared across pods",
+			components: []v1.Component{
+				getContainerComponent("runtime", false, []v1.VolumeMount{testingutil.GetFakeVolumeMount("data", "")}, nil),
+				getContainerComponent("db", true, []v1.VolumeMount{testingutil.GetFakeVolumeMount("data", "")}, nil),
+				testingutil.GetFakeVolumeComponent("data", "1Gi"),
+			},
+			accessMode: corev1.ReadWriteOnce,
+			wantErr:    true,
+		},
+		{
+			name: "Case 5: Volume mount without volume component",
+			components: []v1.Component{
+				getContainerComponent("runtime", false, []v1.VolumeMount{testingutil.GetFakeVolumeMount("data", "")}, nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 6: Invalid volume size",
+			components: []v1.Component{
+				getContainerComponent("runtime", false, []v1.VolumeMount{testingutil.GetFakeVolumeMount("data", "")}, nil),
+				testingutil.GetFakeVolumeComponent("data", "1GB"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devObj := parser.DevfileObj{
+				Data: &testingutil.TestDevfileData{
+					Components: tt.components,
+				},
+			}
+			deploymentParams := PodDeploymentParams{
+				ObjectMeta:        GetObjectMeta("myapp", "testns", map[string]string{"app": "myapp"}, nil),
+				PodSelectorLabels: map[string]string{"component": "myapp"},
+				VolumeAccessMode:  tt.accessMode,
+			}
+
+			podDeployments, pvcs, err := GetPodDeployments(devObj, deploymentParams, common.DevfileOptions{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestGetPodDeployments error: %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(podDeployments) != len(tt.wantDeployments) {
+				t.Errorf("TestGetPodDeployments error: deployments length mismatch - got: %d, wanted: %d", len(podDeployments), len(tt.wantDeployments))
+				return
+			}
+			for i, podDeployment := range podDeployments {
+				deployment := podDeployment.Deployment
+				if deployment.Name != tt.wantDeployments[i] {
+					t.Errorf("TestGetPodDeployments error: deployment name mismatch - got: %s, wanted: %s", deployment.Name, tt.wantDeployments[i])
+				}
+				if !reflect.DeepEqual(podDeployment.ComponentNames, tt.wantComponents[i]) {
+					t.Errorf("TestGetPodDeployments error: components mismatch - got: %v, wanted: %v", podDeployment.ComponentNames, tt.wantComponents[i])
+				}
+				wantSelector := map[string]string{"component": "myapp", DevfilePodLabel: tt.wantDeployments[i]}
+				if !reflect.DeepEqual(deployment.Spec.Selector.MatchLabels, wantSelector) {
+					t.Errorf("TestGetPodDeployments error: selector mismatch - got: %v, wanted: %v", deployment.Spec.Selector.MatchLabels, wantSelector)
+				}
+				for key, value := range wantSelector {
+					if deployment.Spec.Template.Labels[key] != value {
+						t.Errorf("TestGetPodDeployments error: pod template label %s mismatch - got: %s, wanted: %s", key, deployment.Spec.Template.Labels[key], value)
+					}
+				}
+				if len(deployment.Spec.Template.Spec.Containers) != len(tt.wantComponents[i]) {
+					t.Errorf("TestGetPodDeployments error: containers length mismatch - got: %d, wanted: %d", len(deployment.Spec.Template.Spec.Containers), len(tt.wantComponents[i]))
+				}
+
+				var volumes []string
+				for _, volume := range deployment.Spec.Template.Spec.Volumes {
+					volumes = append(volumes, volume.Name)
+					if volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.ClaimName != "myapp-"+volume.Name {
+						t.Errorf("TestGetPodDeployments error: volume %s is not backed by the pvc myapp-%s", volume.Name, volume.Name)
+					}
+				}
+				if !reflect.DeepEqual(volumes, tt.wantVolumes[i]) {
+					t.Errorf("TestGetPodDeployments error: volumes mismatch - got: %v, wanted: %v", volumes, tt.wantVolumes[i])
+				}
+
+				if tt.wantServices[i] == "" {
+					if podDeployment.Service != nil {
+						t.Errorf("TestGetPodDeployments error: unexpected service %s", podDeployment.Service.Name)
+					}
+				} else if podDeployment.Service == nil || podDeployment.Service.Name != tt.wantServices[i] {
+					t.Errorf("TestGetPodDeployments error: service mismatch - got: %v, wanted: %s", podDeployment.Service, tt.wantServices[i])
+				} else if !reflect.DeepEqual(podDeployment.Service.Spec.Selector, wantSelector) {
+					t.Errorf("TestGetPodDeployments error: service selector mismatch - got: %v, wanted: %v", podDeployment.Service.Spec.Selector, wantSelector)
+				}
+			}
+
+			if len(pvcs) != len(tt.wantPVCs) {
+				t.Errorf("TestGetPodDeployments error: pvcs length mismatch - got: %d, wanted: %d", len(pvcs), len(tt.wantPVCs))
+				return
+			}
+			for i, pvc := range pvcs {
+				if pvc.Name != tt.wantPVCs[i] {
+					t.Errorf("TestGetPodDeployments error: pvc name mismatch - got: %s, wanted: %s", pvc.Name, tt.wantPVCs[i])
+				}
+				if !reflect.DeepEqual(pvc.Spec.AccessModes, []corev1.PersistentVolumeAccessMode{tt.wantAccessModes[i]}) {
+					t.Errorf("TestGetPodDeployments error: pvc access modes mismatch - got: %v, wanted: %v", pvc.Spec.AccessModes, tt.wantAccessModes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetPodDeploymentsPreStart(t *testing.T) {
+
+	tests := []struct {
+		name               string
+		options            common.DevfileOptions
+		wantInitContainers []string
+		wantErr            bool
+	}{
+		{
+			name:               "Case 1: preStart init containers in the deployment of their component",
+			wantInitContainers: []string{"prestart-install", "prestart-drain"},
+		},
+		{
+			name: "Case 2: preStart component excluded by the filter options",
+			options: common.DevfileOptions{
+				Filter: map[string]interface{}{
+					"tier": "frontend",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devObj := getEventsTestDevfileObj(v1.Events{
+				WorkspaceEvents: v1.WorkspaceEvents{
+					PreStart: []string{"setup", "drain"},
+				},
+			})
+			devfileData := devObj.Data.(*testingutil.TestDevfileData)
+			devfileData.Components[0].Attributes = attributes.Attributes{}.FromStringMap(map[string]string{
+				"tier": "frontend",
+			})
+			deploymentParams := PodDeploymentParams{
+				ObjectMeta: GetObjectMeta("myapp", "testns", nil, nil),
+			}
+
+			podDeployments, _, err := GetPodDeployments(devObj, deploymentParams, tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestGetPodDeploymentsPreStart error: %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(podDeployments) != 1 {
+				t.Errorf("TestGetPodDeploymentsPreStart error: deployments length mismatch - got: %d, wanted: 1", len(podDeployments))
+				return
+			}
+			var initContainers []string
+			for _, container := range podDeployments[0].Deployment.Spec.Template.Spec.InitContainers {
+				initContainers = append(initContainers, container.Name)
+			}
+			if !reflect.DeepEqual(initContainers, tt.wantInitContainers) {
+				t.Errorf("TestGetPodDeploymentsPreStart error: init containers mismatch - got: %v, wanted: %v", initContainers, tt.wantInitContainers)
+			}
+		})
+	}
+}
+
+const seedJobManifest = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: seed
+spec:
+  template:
+    spec:
+      restartPolicy: Never
+      containers:
+      - name: seed
+        image: seed-image
+`
+
+func getEventsTestDevfileObj(events v1.Events) parser.DevfileObj {
+	mountSources := false
+	return parser.DevfileObj{
+		Data: &testingutil.TestDevfileData{
+			Components: []v1.Component{
+				{
+					Name: "runtime",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{
+							Container: v1.Container{
+								Image:        "runtime-image",
+								MountSources: &mountSources,
+								VolumeMounts: []v1.VolumeMount{testingutil.GetFakeVolumeMount("data", "/data")},
+							},
+							Endpoints: []v1.Endpoint{
+								{
+									Name:       "http",
+									TargetPort: 8080,
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: "tools",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{
+							Container: v1.Container{
+								Image:        "tools-image",
+								MountSources: &mountSources,
+								Command:      []string{"migrate"},
+							},
+						},
+					},
+				},
+				testingutil.GetFakeVolumeComponent("data", "1Gi"),
+				{
+					Name: "seed",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{
+							K8sLikeComponent: v1.K8sLikeComponent{
+								K8sLikeComponentLocation: v1.K8sLikeComponentLocation{
+									Inlined: seedJobManifest,
+								},
+							},
+						},
+					},
+				},
+			},
+			Commands: []v1.Command{
+				{
+					Id: "install",
+					CommandUnion: v1.CommandUnion{
+						Exec: &v1.ExecCommand{
+							CommandLine: "npm install",
+							Component:   "runtime",
+							WorkingDir:  "$PROJECTS_ROOT",
+						},
+					},
+				},
+				{
+					Id: "warmup",
+					CommandUnion: v1.CommandUnion{
+						Exec: &v1.ExecCommand{
+							CommandLine: "curl localhost:8080",
+							Component:   "runtime",
+						},
+					},
+				},
+				{
+					Id: "Drain",
+					CommandUnion: v1.CommandUnion{
+						Exec: &v1.ExecCommand{
+							CommandLine: "kill -TERM 1",
+							Component:   "tools",
+						},
+					},
+				},
+				{
+					Id: "migrate",
+					CommandUnion: v1.CommandUnion{
+						Apply: &v1.ApplyCommand{
+							Component: "tools",
+						},
+					},
+				},
+				{
+					Id: "seed-db",
+					CommandUnion: v1.CommandUnion{
+						Apply: &v1.ApplyCommand{
+							Component: "seed",
+						},
+					},
+				},
+				{
+					Id: "setup",
+					CommandUnion: v1.CommandUnion{
+						Composite: &v1.CompositeCommand{
+							Commands: []string{"install", "migrate"},
+						},
+					},
+				},
+			},
+			Events: events,
+		},
+	}
+}
+
+func TestGetInitContainers(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		events   v1.Events
+		want     []string
+		wantCmds [][]string
+		wantErr  bool
+	}{
+		{
+			name: "Case 1: preStart exec commands with composite expanded",
+			events: v1.Events{
+				WorkspaceEvents: v1.WorkspaceEvents{
+					PreStart: []string{"setup", "drain"},
+				},
+			},
+			want: []string{"prestart-install", "prestart-drain"},
+			wantCmds: [][]string{
+				{"/bin/sh", "-c", `cd "$PROJECTS_ROOT" && npm install`},
+				{"/bin/sh", "-c", "kill -TERM 1"},
+			},
+		},
+		{
+			name: "Case 2: preStart exec command run twice",
+			events: v1.Events{
+				WorkspaceEvents: v1.WorkspaceEvents{
+					PreStart: []string{"install", "setup"},
+				},
+			},
+			want: []string{"prestart-install", "prestart-install-2"},
+			wantCmds: [][]string{
+				{"/bin/sh", "-c", `cd "$PROJECTS_ROOT" && npm install`},
+				{"/bin/sh", "-c", `cd "$PROJECTS_ROOT" && npm install`},
+			},
+		},
+		{
+			name: "Case 3: No preStart event",
+			events: v1.Events{
+				WorkspaceEvents: v1.WorkspaceEvents{
+					PostStart: []string{"warmup"},
+				},
+			},
+		},
+		{
+			name: "Case 4: Undefined preStart command",
+			events: v1.Events{
+				WorkspaceEvents: v1.WorkspaceEvents{
+					PreStart: []string{"build"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initContainers, err := GetInitContainers(getEventsTestDevfileObj(tt.events), ContainerResourceParams{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestGetInitContainers error: %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(initContainers) != len(tt.want) {
+				t.Errorf("TestGetInitContainers error: init containers length mismatch - got: %d, wanted: %d", len(initContainers), len(tt.want))
+				return
+			}
+			for i, initContainer := range initContainers {
+				if initContainer.Name != tt.want[i] {
+					t.Errorf("TestGetInitContainers error: name mismatch - got: %s, wanted: %s", initContainer.Name, tt.want[i])
+				}
+				if !reflect.DeepEqual(initContainer.Command, tt.wantCmds[i]) {
+					t.Errorf("TestGetInitContainers error: command mismatch - got: %v, wanted: %v", initContainer.Command, tt.wantCmds[i])
+				}
+				if len(initContainer.Ports) != 0 {
+					t.Errorf("TestGetInitContainers error: unexpected ports %v", initContainer.Ports)
+				}
+			}
+		})
+	}
+}
+
+func TestAddLifecycleHooks(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		events        v1.Events
+		wantPostStart map[string][]string
+		wantPreStop   map[string][]string
+		wantErr       bool
+	}{
+		{
+			name: "Case 1: postStart and preStop hooks",
+			events: v1.Events{
+				WorkspaceEvents: v1.WorkspaceEvents{
+					PostStart: []string{"setup", "warmup"},
+					PreStop:   []string{"drain"},
+				},
+			},
+			wantPostStart: map[string][]string{
+				"runtime": {"/bin/sh", "-c", `(cd "$PROJECTS_ROOT" && npm install) && (curl localhost:8080)`},
+			},
+			wantPreStop: map[string][]string{
+				"tools": {"/bin/sh", "-c", "(kill -TERM 1)"},
+			},
+		},
+		{
+			name: "Case 2: Composite command cycle",
+			events: v1.Events{
+				WorkspaceEvents: v1.WorkspaceEvents{
+					PostStart: []string{"loop"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devObj := getEventsTestDevfileObj(tt.events)
+			devfileData := devObj.Data.(*testingutil.TestDevfileData)
+			devfileData.Commands = append(devfileData.Commands, v1.Command{
+				Id: "loop",
+				CommandUnion: v1.CommandUnion{
+					Composite: &v1.CompositeCommand{
+						Commands: []string{"install", "loop"},
+					},
+				},
+			})
+			containers, err := GetContainers(devObj, common.DevfileOptions{})
+			if err != nil {
+				t.Errorf("TestAddLifecycleHooks unexpected error: %v", err)
+				return
+			}
+
+			err = AddLifecycleHooks(devObj, containers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestAddLifecycleHooks error: %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			for _, container := range containers {
+				var postStart, preStop []string
+				if container.Lifecycle != nil && container.Lifecycle.PostStart != nil {
+					postStart = container.Lifecycle.PostStart.Exec.Command
+				}
+				if container.Lifecycle != nil && container.Lifecycle.PreStop != nil {
+					preStop = container.Lifecycle.PreStop.Exec.Command
+				}
+				if !reflect.DeepEqual(postStart, tt.wantPostStart[container.Name]) {
+					t.Errorf("TestAddLifecycleHooks error: postStart mismatch for %s - got: %v, wanted: %v", container.Name, postStart, tt.wantPostStart[container.Name])
+				}
+				if !reflect.DeepEqual(preStop, tt.wantPreStop[container.Name]) {
+					t.Errorf("TestAddLifecycleHooks error: preStop mismatch for %s - got: %v, wanted: %v", container.Name, preStop, tt.wantPreStop[container.Name])
+				}
+			}
+		})
+	}
+}
+
+func TestGetEventJobs(t *testing.T) {
+
+	events := v1.Events{
+		WorkspaceEvents: v1.WorkspaceEvents{
+			PreStart:  []string{"setup", "seed-db"},
+			PostStart: []string{"warmup"},
+			PostStop:  []string{"drain", "migrate"},
+		},
+	}
+	jobParams := EventJobParams{
+		ObjectMeta: GetObjectMeta("myapp", "testns", nil, nil),
+	}
+
+	eventJobs, err := GetEventJobs(getEventsTestDevfileObj(events), jobParams)
+	if err != nil {
+		t.Errorf("TestGetEventJobs unexpected error: %v", err)
+		return
+	}
+
+	getJobNames := func(jobs []batchv1.Job) []string {
+		var names []string
+		for _, job := range jobs {
+			names = append(names, job.Name)
+			if job.Namespace != "testns" {
+				t.Errorf("TestGetEventJobs error: namespace mismatch - got: %s, wanted: testns", job.Namespace)
+			}
+			if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyNever {
+				t.Errorf("TestGetEventJobs error: restart policy mismatch - got: %s", job.Spec.Template.Spec.RestartPolicy)
+			}
+		}
+		return names
+	}
+	if names := getJobNames(eventJobs.PreStart); !reflect.DeepEqual(names, []string{"myapp-prestart-migrate", "seed"}) {
+		t.Errorf("TestGetEventJobs error: preStart jobs mismatch - got: %v", names)
+	}
+	if names := getJobNames(eventJobs.PostStart); names != nil {
+		t.Errorf("TestGetEventJobs error: postStart jobs mismatch - got: %v", names)
+	}
+	if names := getJobNames(eventJobs.PostStop); !reflect.DeepEqual(names, []string{"myapp-poststop-drain", "myapp-poststop-migrate"}) {
+		t.Errorf("TestGetEventJobs error: postStop jobs mismatch - got: %v", names)
+	}
+
+	applyContainer := eventJobs.PreStart[0].Spec.Template.Spec.Containers[0]
+	if applyContainer.Image != "tools-image" || !reflect.DeepEqual(applyContainer.Command, []string{"migrate"}) {
+		t.Errorf("TestGetEventJobs error: apply job container mismatch - got: %v", applyContainer)
+	}
+	seedContainer := eventJobs.PreStart[1].Spec.Template.Spec.Containers[0]
+	if seedContainer.Image != "seed-image" {
+		t.Errorf("TestGetEventJobs error: manifest job container mismatch - got: %v", seedContainer)
+	}
+	execContainer := eventJobs.PostStop[0].Spec.Template.Spec.Containers[0]
+	if !reflect.DeepEqual(execContainer.Command, []string{"/bin/sh", "-c", "kill -TERM 1"}) {
+		t.Errorf("TestGetEventJobs error: exec job command mismatch - got: %v", execContainer.Command)
+	}
+}
+
+func getPublicEndpointsTestComponents() []v1.Component {
+	return []v1.Component{
+		{
+			Name: "runtime",
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Endpoints: []v1.Endpoint{
+						{
+							Name:       "http",
+							TargetPort: 8080,
+						},
+						{
+							Name:       "api",
+							TargetPort: 8443,
+							Path:       "/api",
+							Secure:     true,
+							Exposure:   v1.PublicEndpointExposure,
+						},
+						{
+							Name:       "metrics",
+							TargetPort: 9090,
+							Exposure:   v1.InternalEndpointExposure,
+						},
+						{
+							Name:       "dns",
+							TargetPort: 5353,
+							Protocol:   v1.UDPEndpointProtocol,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "tools",
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Endpoints: []v1.Endpoint{
+						{
+							Name:       "debug",
+							TargetPort: 5858,
+							Exposure:   v1.NoneEndpointExposure,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetEndpointIngresses(t *testing.T) {
+
+	tests := []struct {
+		name             string
+		ingressParams    EndpointIngressParams
+		wantIngressNames []string
+		wantHosts        []string
+		wantServiceNames []string
+		wantPaths        []string
+		wantTLS          []bool
+		wantErr          bool
+	}{
+		{
+			name: "Case 1: networking v1 ingresses targeting the component service",
+			ingressParams: EndpointIngressParams{
+				ObjectMeta:    GetObjectMeta("myapp", "testns", nil, nil),
+				HostTemplate:  "{{.Endpoint}}-{{.Namespace}}.example.com",
+				TLSSecretName: "tls-secret",
+			},
+			wantIngressNames: []string{"myapp-http", "myapp-api"},
+			wantHosts:        []string{"http-testns.example.com", "api-testns.example.com"},
+			wantServiceNames: []string{"myapp-runtime", "myapp-runtime"},
+			wantPaths:        []string{"/", "/api"},
+			wantTLS:          []bool{false, true},
+		},
+		{
+			name: "Case 2: extensions v1beta1 ingresses targeting a given service",
+			ingressParams: EndpointIngressParams{
+				ObjectMeta:  GetObjectMeta("myapp", "testns", nil, nil),
+				ServiceName: "myservice",
+				APIVersion:  ExtensionsV1Beta1IngressAPIVersion,
+			},
+			wantIngressNames: []string{"myapp-http", "myapp-api"},
+			wantHosts:        []string{"", ""},
+			wantServiceNames: []string{"myservice", "myservice"},
+			wantPaths:        []string{"/", "/api"},
+			wantTLS:          []bool{false, false},
+		},
+		{
+			name: "Case 3: unsupported api version",
+			ingressParams: EndpointIngressParams{
+				APIVersion: "networking.k8s.io/v1beta2",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 4: invalid host template",
+			ingressParams: EndpointIngressParams{
+				HostTemplate: "{{.Unknown}}.example.com",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devObj := parser.DevfileObj{
+				Data: &testingutil.TestDevfileData{
+					Components: getPublicEndpointsTestComponents(),
+				},
+			}
+
+			ingresses, err := GetEndpointIngresses(devObj, tt.ingressParams, common.DevfileOptions{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestGetEndpointIngresses() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(ingresses) != len(tt.wantIngressNames) {
+				t.Errorf("TestGetEndpointIngresses error: ingresses length mismatch - got: %d, wanted: %d", len(ingresses), len(tt.wantIngressNames))
+				return
+			}
+			for i, ingress := range ingresses {
+				var name, host, serviceName, path string
+				var hasTLS bool
+				if ingress.NetworkingV1 != nil {
+					name = ingress.NetworkingV1.Name
+					rule := ingress.NetworkingV1.Spec.Rules[0]
+					host = rule.Host
+					serviceName = rule.HTTP.Paths[0].Backend.Service.Name
+					path = rule.HTTP.Paths[0].Path
+					hasTLS = len(ingress.NetworkingV1.Spec.TLS) > 0
+				} else if ingress.ExtensionsV1Beta1 != nil {
+					name = ingress.ExtensionsV1Beta1.Name
+					rule := ingress.ExtensionsV1Beta1.Spec.Rules[0]
+					host = rule.Host
+					serviceName = rule.HTTP.Paths[0].Backend.ServiceName
+					path = rule.HTTP.Paths[0].Path
+					hasTLS = len(ingress.ExtensionsV1Beta1.Spec.TLS) > 0
+				} else {
+					t.Errorf("TestGetEndpointIngresses error: ingress %d is empty", i)
+					continue
+				}
+				if (ingress.ExtensionsV1Beta1 != nil) != (tt.ingressParams.APIVersion == ExtensionsV1Beta1IngressAPIVersion) {
+					t.Errorf("TestGetEndpointIngresses error: ingress %d has the wrong api version", i)
+				}
+				if name != tt.wantIngressNames[i] {
+					t.Errorf("TestGetEndpointIngresses error: Name mismatch - got: %s, wanted: %s", name, tt.wantIngressNames[i])
+				}
+				if host != tt.wantHosts[i] {
+					t.Errorf("TestGetEndpointIngresses error: Host mismatch - got: %s, wanted: %s", host, tt.wantHosts[i])
+				}
+				if serviceName != tt.wantServiceNames[i] {
+					t.Errorf("TestGetEndpointIngresses error: Service name mismatch - got: %s, wanted: %s", serviceName, tt.wantServiceNames[i])
+				}
+				if path != tt.wantPaths[i] {
+					t.Errorf("TestGetEndpointIngresses error: Path mismatch - got: %s, wanted: %s", path, tt.wantPaths[i])
+				}
+				if hasTLS != tt.wantTLS[i] {
+					t.Errorf("TestGetEndpointIngresses error: TLS mismatch - got: %v, wanted: %v", hasTLS, tt.wantTLS[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetEndpointRoutes(t *testing.T) {
+
+	devObj := parser.DevfileObj{
+		Data: &testingutil.TestDevfileData{
+			Components: getPublicEndpointsTestComponents(),
+		},
+	}
+	routeParams := EndpointRouteParams{
+		ObjectMeta: GetObjectMeta("myapp", "testns", map[string]string{"component": "myapp"}, nil),
+	}
+
+	routes, err := GetEndpointRoutes(devObj, routeParams, common.DevfileOptions{})
+	if err != nil {
+		t.Errorf("TestGetEndpointRoutes unexpected error: %v", err)
+		return
+	}
+
+	wantNames := []string{"myapp-http", "myapp-api"}
+	wantPaths := []string{"/", "/api"}
+	wantSecure := []bool{false, true}
+	if len(routes) != len(wantNames) {
+		t.Errorf("TestGetEndpointRoutes error: routes length mismatch - got: %d, wanted: %d", len(routes), len(wantNames))
+		return
+	}
+	for i, route := range routes {
+		if route.Name != wantNames[i] {
+			t.Errorf("TestGetEndpointRoutes error: Name mismatch - got: %s, wanted: %s", route.Name, wantNames[i])
+		}
+		if !reflect.DeepEqual(route.Labels, routeParams.ObjectMeta.Labels) {
+			t.Errorf("TestGetEndpointRoutes error: Labels mismatch - got: %v, wanted: %v", route.Labels, routeParams.ObjectMeta.Labels)
+		}
+		if route.Spec.To.Name != "myapp-runtime" {
+			t.Errorf("TestGetEndpointRoutes error: Service name mismatch - got: %s, wanted: myapp-runtime", route.Spec.To.Name)
+		}
+		if route.Spec.Path != wantPaths[i] {
+			t.Errorf("TestGetEndpointRoutes error: Path mismatch - got: %s, wanted: %s", route.Spec.Path, wantPaths[i])
+		}
+		if (route.Spec.TLS != nil) != wantSecure[i] {
+			t.Errorf("TestGetEndpointRoutes error: TLS mismatch - got: %v, wanted secure: %v", route.Spec.TLS, wantSecure[i])
+		}
+	}
+}
+
+func TestGetIngressForAPIVersion(t *testing.T) {
+
+	ingressParams := IngressParams{
+		ObjectMeta: GetObjectMeta("myingress", "testns", nil, nil),
+		IngressSpecParams: IngressSpecParams{
+			ServiceName:      "myservice",
+			IngressDomain:    "myapp.example.com",
+			PortNumber:       intstr.FromInt(8080),
+			PathType:         networkingv1.PathTypePrefix,
+			IngressClassName: "nginx",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		apiVersion string
+		wantErr    bool
+	}{
+		{
+			name:       "Case 1: default api version",
+			apiVersion: "",
+		},
+		{
+			name:       "Case 2: networking v1",
+			apiVersion: NetworkingV1IngressAPIVersion,
+		},
+		{
+			name:       "Case 3: extensions v1beta1",
+			apiVersion: ExtensionsV1Beta1IngressAPIVersion,
+		},
+		{
+			name:       "Case 4: unsupported api version",
+			apiVersion: "networking.k8s.io/v1beta1",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ingress, err := GetIngressForAPIVersion(ingressParams, tt.apiVersion)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestGetIngressForAPIVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if tt.apiVersion == ExtensionsV1Beta1IngressAPIVersion {
+				if ingress.ExtensionsV1Beta1 == nil || ingress.NetworkingV1 != nil {
+					t.Errorf("TestGetIngressForAPIVersion error: expected an extensions v1beta1 ingress, got %+v", ingress)
+					return
+				}
+				if ingress.ExtensionsV1Beta1.APIVersion != ExtensionsV1Beta1IngressAPIVersion {
+					t.Errorf("TestGetIngressForAPIVersion error: api version mismatch - got: %s", ingress.ExtensionsV1Beta1.APIVersion)
+				}
+				path := ingress.ExtensionsV1Beta1.Spec.Rules[0].HTTP.Paths[0]
+				if path.PathType == nil || *path.PathType != extensionsv1.PathTypePrefix {
+					t.Errorf("TestGetIngressForAPIVersion error: path type mismatch - got: %v", path.PathType)
+				}
+				if className := ingress.ExtensionsV1Beta1.Spec.IngressClassName; className == nil || *className != "nginx" {
+					t.Errorf("TestGetIngressForAPIVersion error: ingress class mismatch - got: %v", className)
+				}
+				return
+			}
+
+			if ingress.NetworkingV1 == nil || ingress.ExtensionsV1Beta1 != nil {
+				t.Errorf("TestGetIngressForAPIVersion error: expected a networking v1 ingress, got %+v", ingress)
+				return
+			}
+			if ingress.NetworkingV1.APIVersion != NetworkingV1IngressAPIVersion {
+				t.Errorf("TestGetIngressForAPIVersion error: api version mismatch - got: %s", ingress.NetworkingV1.APIVersion)
+			}
+			path := ingress.NetworkingV1.Spec.Rules[0].HTTP.Paths[0]
+			if path.PathType == nil || *path.PathType != networkingv1.PathTypePrefix {
+				t.Errorf("TestGetIngressForAPIVersion error: path type mismatch - got: %v", path.PathType)
+			}
+			if className := ingress.NetworkingV1.Spec.IngressClassName; className == nil || *className != "nginx" {
+				t.Errorf("TestGetIngressForAPIVersion error: ingress class mismatch - got: %v", className)
+			}
+		})
+	}
+}
+
+// fakeDiscovery is a fake ServerResourcesDiscovery serving the given group versions
+type fakeDiscovery struct {
+	resources map[string]*metav1.APIResourceList
+	err       error
+}
+
+func (d fakeDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if resources, ok := d.resources[groupVersion]; ok {
+		return resources, nil
+	}
+	return nil, kerrors.NewNotFound(schema.GroupResource{Group: groupVersion}, "")
+}
+
+func TestGetIngressAPIVersion(t *testing.T) {
+
+	extensionsResources := &metav1.APIResourceList{
+		APIResources: []metav1.APIResource{
+			{Name: "ingresses", Kind: "Ingress"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		discovery      fakeDiscovery
+		wantAPIVersion string
+		wantErr        bool
+	}{
+		{
+			name: "Case 1: cluster serves networking v1 ingresses",
+			discovery: fakeDiscovery{
+				resources: map[string]*metav1.APIResourceList{
+					NetworkingV1IngressAPIVersion: {
+						APIResources: []metav1.APIResource{
+							{Name: "networkpolicies", Kind: "NetworkPolicy"},
+							{Name: "ingresses", Kind: "Ingress"},
+						},
+					},
+				},
+			},
+			wantAPIVersion: NetworkingV1IngressAPIVersion,
+		},
+		{
+			name: "Case 2: cluster serves networking v1 without ingresses",
+			discovery: fakeDiscovery{
+				resources: map[string]*metav1.APIResourceList{
+					NetworkingV1IngressAPIVersion: {
+						APIResources: []metav1.APIResource{
+							{Name: "networkpolicies", Kind: "NetworkPolicy"},
+						},
+					},
+					ExtensionsV1Beta1IngressAPIVersion: extensionsResources,
+				},
+			},
+			wantAPIVersion: ExtensionsV1Beta1IngressAPIVersion,
+		},
+		{
+			name: "Case 3: cluster doesn't serve networking v1",
+			discovery: fakeDiscovery{
+				resources: map[string]*metav1.APIResourceList{
+					ExtensionsV1Beta1IngressAPIVersion: extensionsResources,
+				},
+			},
+			wantAPIVersion: ExtensionsV1Beta1IngressAPIVersion,
+		},
+		{
+			name:      "Case 4: cluster serves neither ingress api version",
+			discovery: fakeDiscovery{},
+			wantErr:   true,
+		},
+		{
+			name: "Case 5: discovery error",
+			discovery: fakeDiscovery{
+				err: fmt.Errorf("connection refused"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiVersion, err := GetIngressAPIVersion(tt.discovery)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestGetIngressAPIVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if apiVersion != tt.wantAPIVersion {
+				t.Errorf("TestGetIngressAPIVersion error: api version mismatch - got: %s, wanted: %s", apiVersion, tt.wantAPIVersion)
+			}
+		})
+	}
+}
+
+func TestGetDockerBuildInfo(t *testing.T) {
+	var err error
+	devfileObj := parser.DevfileObj{
+		Data: &v2.DevfileV2{
+			Devfile: v1.Devfile{
+				DevfileHeader: devfilepkg.DevfileHeader{
+					Metadata: devfilepkg.DevfileMetadata{
+						Name: "nodejs",
+						Attributes: attributes.Attributes{}.FromMap(map[string]interface{}{
+							BuildDockerfileAttribute: "docker/Dockerfile",
+							BuildArgsAttribute:       map[string]string{"VERSION": "12"},
+						}, &err),
+					},
+				},
+			},
+		},
+	}
+
+	// the build information is read without a git project
+	buildInfo, err := GetDockerBuildInfo(devfileObj)
+	if err != nil {
+		t.Fatalf("TestGetDockerBuildInfo() unexpected error %v", err)
+	}
+	want := DockerBuildInfo{
+		DockerfilePath: "docker/Dockerfile",
+		BuildContext:   ".",
+		BuildArgs:      []corev1.EnvVar{{Name: "VERSION", Value: "12"}},
+	}
+	if !reflect.DeepEqual(buildInfo, want) {
+		t.Errorf("TestGetDockerBuildInfo() build info mismatch - got: %v, wanted: %v", buildInfo, want)
+	}
+	if _, err := GetDockerBuildSource(devfileObj, ""); err == nil {
+		t.Errorf("TestGetDockerBuildInfo() expected an error for the build source of a devfile without git project")
+	}
+}
+
+func TestGetDockerBuildConfig(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		buildAttributes map[string]interface{}
+		projectName     string
+		wantDockerfile  string
+		wantContextDir  string
+		wantBuildArgs   []corev1.EnvVar
+		wantGitURL      string
+		wantErr         bool
+	}{
+		{
+			name: "case 1: Dockerfile at the root of the first git project",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "./Dockerfile",
+			},
+			wantDockerfile: "Dockerfile",
+			wantGitURL:     "https://github.com/someproject/test-project.git",
+		},
+		{
+			name: "case 2: Dockerfile in a build context with build args",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "docker/app/Dockerfile",
+				BuildContextAttribute:    "docker",
+				BuildArgsAttribute:       map[string]string{"VERSION": "12", "MODE": "production"},
+			},
+			projectName:    "anotherproject",
+			wantDockerfile: "app/Dockerfile",
+			wantContextDir: "docker",
+			wantBuildArgs:  []corev1.EnvVar{{Name: "MODE", Value: "production"}, {Name: "VERSION", Value: "12"}},
+			wantGitURL:     "https://github.com/another/project.git",
+		},
+		{
+			name: "case 3: Dockerfile outside of the build context",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "Dockerfile",
+				BuildContextAttribute:    "app",
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 4: Dockerfile outside of the devfile directory",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "../Dockerfile",
+			},
+			wantErr: true,
+		},
+		{
+			name:            "case 5: no Dockerfile attribute",
+			buildAttributes: map[string]interface{}{},
+			wantErr:         true,
+		},
+		{
+			name: "case 6: missing project",
+			buildAttributes: map[string]interface{}{
+				BuildDockerfileAttribute: "Dockerfile",
+			},
+			projectName: "missing",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objectMeta := GetObjectMeta("nodejs", "testns", map[string]string{"component": "nodejs"}, nil)
+			buildConfig, imageStream, err := GetDockerBuildConfig(parser.DevfileObj{Data: testingutil.GetFakeDevfileDataWithMetadataAttributes(tt.buildAttributes)}, DockerBuildParams{
+				ObjectMeta:  objectMeta,
+				ProjectName: tt.projectName,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TestGetDockerBuildConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if buildConfig.Kind != buildConfigKind || !reflect.DeepEqual(buildConfig.ObjectMeta, objectMeta) {
+				t.Errorf("TestGetDockerBuildConfig() build config mismatch - got: %v %v", buildConfig.TypeMeta, buildConfig.ObjectMeta)
+			}
+			if imageStream.Kind != imageStreamKind || !reflect.DeepEqual(imageStream.ObjectMeta, objectMeta) {
+				t.Errorf("TestGetDockerBuildConfig() image stream mismatch - got: %v %v", imageStream.TypeMeta, imageStream.ObjectMeta)
+			}
+			if buildConfig.Spec.Output.To.Name != "nodejs:latest" {
+				t.Errorf("TestGetDockerBuildConfig() output mismatch - got: %s, wanted: nodejs:latest", buildConfig.Spec.Output.To.Name)
+			}
+			if buildConfig.Spec.Source.Git.URI != tt.wantGitURL {
+				t.Errorf("TestGetDockerBuildConfig() git url mismatch - got: %s, wanted: %s", buildConfig.Spec.Source.Git.URI, tt.wantGitURL)
+			}
+			if buildConfig.Spec.Source.ContextDir != tt.wantContextDir {
+				t.Errorf("TestGetDockerBuildConfig() context dir mismatch - got: %s, wanted: %s", buildConfig.Spec.Source.ContextDir, tt.wantContextDir)
+			}
+			dockerStrategy := buildConfig.Spec.Strategy.DockerStrategy
+			if dockerStrategy.DockerfilePath != tt.wantDockerfile {
+				t.Errorf("TestGetDockerBuildConfig() Dockerfile mismatch - got: %s, wanted: %s", dockerStrategy.DockerfilePath, tt.wantDockerfile)
+			}
+			if !reflect.DeepEqual(dockerStrategy.BuildArgs, tt.wantBuildArgs) {
+				t.Errorf("TestGetDockerBuildConfig() build args mismatch - got: %v, wanted: %v", dockerStrategy.BuildArgs, tt.wantBuildArgs)
+			}
+		})
+	}
+}
+
+func TestGetKanikoBuildJob(t *testing.T) {
+
+	devObj := parser.DevfileObj{
+		Data: testingutil.GetFakeDevfileDataWithMetadataAttributes(map[string]interface{}{
+			BuildDockerfileAttribute: "docker/Dockerfile",
+			BuildArgsAttribute:       []string{"VERSION=12", "FLAGS=--prod=true"},
+		}),
+	}
+
+	tests := []struct {
+		name           string
+		jobParams      KanikoJobParams
+		wantArgs       []string
+		wantSecretName string
+	}{
+		{
+			name: "case 1: push the image with the push secret",
+			jobParams: KanikoJobParams{
+				Image:          "quay.io/user/nodejs:latest",
+				PushSecretName: "quay-secret",
+			},
+			wantArgs: []string{
+				"--dockerfile=/workspace/source/docker/Dockerfile",
+				"--context=dir:///workspace/source",
+				"--build-arg=VERSION=12",
+				"--build-arg=FLAGS=--prod=true",
+				"--destination=quay.io/user/nodejs:latest",
+			},
+			wantSecretName: "quay-secret",
+		},
+		{
+			name:      "case 2: build without pushing the image",
+			jobParams: KanikoJobParams{},
+			wantArgs: []string{
+				"--dockerfile=/workspace/source/docker/Dockerfile",
+				"--context=dir:///workspace/source",
+				"--build-arg=VERSION=12",
+				"--build-arg=FLAGS=--prod=true",
+				"--no-push",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.jobParams.ObjectMeta = GetObjectMeta("nodejs-build", "testns", nil, nil)
+			job, err := GetKanikoBuildJob(devObj, tt.jobParams)
+			if err != nil {
+				t.Fatalf("TestGetKanikoBuildJob() unexpected error %v", err)
+			}
+
+			if job.Name != "nodejs-build" || job.Kind != jobKind {
+				t.Errorf("TestGetKanikoBuildJob() job mismatch - got: %v %v", job.TypeMeta, job.Name)
+			}
+			podSpec := job.Spec.Template.Spec
+			if len(podSpec.InitContainers) != 1 || len(podSpec.Containers) != 1 {
+				t.Fatalf("TestGetKanikoBuildJob() containers mismatch - got: %d init containers and %d containers", len(podSpec.InitContainers), len(podSpec.Containers))
+			}
+			wantEnv := []corev1.EnvVar{{Name: "GIT_URL", Value: "https://github.com/someproject/test-project.git"}, {Name: "GIT_REF", Value: ""}}
+			if !reflect.DeepEqual(podSpec.InitContainers[0].Env, wantEnv) || podSpec.InitContainers[0].Image != DefaultGitImage {
+				t.Errorf("TestGetKanikoBuildJob() git clone container mismatch - got: %v %v", podSpec.InitContainers[0].Image, podSpec.InitContainers[0].Env)
+			}
+			kaniko := podSpec.Containers[0]
+			if kaniko.Image != DefaultKanikoImage {
+				t.Errorf("TestGetKanikoBuildJob() kaniko image mismatch - got: %s, wanted: %s", kaniko.Image, DefaultKanikoImage)
+			}
+			if !reflect.DeepEqual(kaniko.Args, tt.wantArgs) {
+				t.Errorf("TestGetKanikoBuildJob() kaniko args mismatch - got: %v, wanted: %v", kaniko.Args, tt.wantArgs)
+			}
+
+			secretName := ""
+			for _, volume := range podSpec.Volumes {
+				if volume.Secret != nil {
+					secretName = volume.Secret.SecretName
+				}
+			}
+			if secretName != tt.wantSecretName {
+				t.Errorf("TestGetKanikoBuildJob() push secret mismatch - got: %q, wanted: %q", secretName, tt.wantSecretName)
+			}
+		})
+	}
+}
@@ -1,22 +1,40 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"net/url"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
+	"text/template"
+	"unicode"
 
 	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/attributes"
+	devfilecommands "github.com/devfile/library/pkg/devfile/commands"
 	"github.com/devfile/library/pkg/devfile/parser"
+	devfileCtx "github.com/devfile/library/pkg/devfile/parser/context"
 	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/devfile/library/pkg/testingutil/filesystem"
 	"github.com/devfile/library/pkg/util"
 	buildv1 "github.com/openshift/api/build/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog"
 )
 
 // convertEnvs converts environment variables from the devfile structure to kubernetes structure
@@ -35,39 +53,108 @@ func convertEnvs(vars []v1.EnvVar) []corev1.EnvVar {
 func convertPorts(endpoints []v1.Endpoint) []corev1.ContainerPort {
 	containerPorts := []corev1.ContainerPort{}
 	for _, endpoint := range endpoints {
-		name := strings.TrimSpace(util.GetDNS1123Name(strings.ToLower(endpoint.Name)))
-		name = util.TruncateString(name, 15)
-
 		containerPorts = append(containerPorts, corev1.ContainerPort{
-			Name:          name,
+			Name:          getPortName(endpoint.Name, endpoint.TargetPort),
 			ContainerPort: int32(endpoint.TargetPort),
+			Protocol:      getPortProtocol(endpoint.Protocol),
 		})
 	}
 	return containerPorts
 }
 
+// getPortName converts the endpoint name into a valid kubernetes port name:
+// at most 15 lowercase alphanumeric characters or '-', with at least one letter
+// if no valid name can be derived from the endpoint name, port-<targetPort> is returned
+func getPortName(endpointName string, targetPort int) string {
+	name := strings.Replace(strings.ToLower(endpointName), "_", "-", -1)
+	name = strings.TrimSpace(util.GetDNS1123Name(name))
+	name = strings.TrimRight(util.TruncateString(name, portNameMaxLength), "-")
+	if strings.IndexFunc(name, unicode.IsLetter) == -1 {
+		name = fmt.Sprintf("port-%d", targetPort)
+	}
+	return name
+}
+
+// getPortProtocol returns the kubernetes protocol of the transport layer of the endpoint protocol
+// udp and sctp endpoints are mapped to their protocol, every other endpoint protocol is carried over TCP
+func getPortProtocol(protocol v1.EndpointProtocol) corev1.Protocol {
+	switch strings.ToLower(string(protocol)) {
+	case string(v1.UDPEndpointProtocol):
+		return corev1.ProtocolUDP
+	case string(SCTPEndpointProtocol):
+		return corev1.ProtocolSCTP
+	default:
+		return corev1.ProtocolTCP
+	}
+}
+
 // getResourceReqs creates a kubernetes ResourceRequirements object based on resource requirements set in the devfile
-func getResourceReqs(comp v1.Component) corev1.ResourceRequirements {
-	reqs := corev1.ResourceRequirements{}
-	limits := make(corev1.ResourceList)
+// defaults holds the resource requirements to use when the devfile doesn't set them, the memoryLimit of the devfile
+// takes precedence over the default memory limit. An error is returned if a quantity is invalid or a request exceeds its limit
+func getResourceReqs(comp v1.Component, defaults ResourceRequirementsParams) (corev1.ResourceRequirements, error) {
 	if comp.Container != nil && comp.Container.MemoryLimit != "" {
-		memoryLimit, err := resource.ParseQuantity(comp.Container.MemoryLimit)
-		if err == nil {
-			limits[corev1.ResourceMemory] = memoryLimit
+		defaults.MemoryLimit = comp.Container.MemoryLimit
+	}
+
+	reqs := corev1.ResourceRequirements{}
+	resources := []struct {
+		name    corev1.ResourceName
+		request string
+		limit   string
+	}{
+		{name: corev1.ResourceMemory, request: defaults.MemoryRequest, limit: defaults.MemoryLimit},
+		{name: corev1.ResourceCPU, request: defaults.CPURequest, limit: defaults.CPULimit},
+		{name: corev1.ResourceEphemeralStorage, request: defaults.EphemeralStorageRequest, limit: defaults.EphemeralStorageLimit},
+	}
+	for _, res := range resources {
+		quantities, err := util.FetchResourceQuantity(res.name, res.request, res.limit, "")
+		if err != nil {
+			return reqs, errors.Wrapf(err, "invalid %s request %q or limit %q for component %s", res.name, res.request, res.limit, comp.Name)
+		}
+		if quantities == nil {
+			continue
+		}
+		request, limit := quantities.MinQty, quantities.MaxQty
+		if res.request != "" {
+			if reqs.Requests == nil {
+				reqs.Requests = make(corev1.ResourceList)
+			}
+			reqs.Requests[res.name] = request
+		}
+		if res.limit != "" {
+			if reqs.Limits == nil {
+				reqs.Limits = make(corev1.ResourceList)
+			}
+			reqs.Limits[res.name] = limit
+		}
+		if res.request != "" && res.limit != "" && request.Cmp(limit) > 0 {
+			return reqs, fmt.Errorf("the %s request %s of component %s is larger than its limit %s", res.name, res.request, comp.Name, res.limit)
+		}
+	}
+	return reqs, nil
+}
+
+// mergeResourceRequirementsParams returns the params with the empty fields set from the defaults
+func mergeResourceRequirementsParams(params, defaults ResourceRequirementsParams) ResourceRequirementsParams {
+	merge := func(value, defaultValue string) string {
+		if value == "" {
+			return defaultValue
 		}
-		reqs.Limits = limits
+		return value
+	}
+	return ResourceRequirementsParams{
+		MemoryRequest:           merge(params.MemoryRequest, defaults.MemoryRequest),
+		MemoryLimit:             merge(params.MemoryLimit, defaults.MemoryLimit),
+		CPURequest:              merge(params.CPURequest, defaults.CPURequest),
+		CPULimit:                merge(params.CPULimit, defaults.CPULimit),
+		EphemeralStorageRequest: merge(params.EphemeralStorageRequest, defaults.EphemeralStorageRequest),
+		EphemeralStorageLimit:   merge(params.EphemeralStorageLimit, defaults.EphemeralStorageLimit),
 	}
-	return reqs
 }
 
 // addSyncRootFolder adds the sync root folder to the container env
 func addSyncRootFolder(container *corev1.Container, sourceMapping string) string {
-	var syncRootFolder string
-	if sourceMapping != "" {
-		syncRootFolder = sourceMapping
-	} else {
-		syncRootFolder = DevfileSourceVolumeMount
-	}
+	syncRootFolder := common.GetProjectsRoot(sourceMapping)
 
 	// Note: PROJECTS_ROOT & PROJECT_SOURCE are validated at the devfile parser level
 	// Add PROJECTS_ROOT to the container
@@ -84,27 +171,9 @@ func addSyncRootFolder(container *corev1.Container, sourceMapping string) string
 // sourceVolumePath: mount path of the empty dir volume to sync source code
 // projects: list of projects from devfile
 func addSyncFolder(container *corev1.Container, sourceVolumePath string, projects []v1.Project) error {
-	var syncFolder string
-
-	// if there are no projects in the devfile, source would be synced to $PROJECTS_ROOT
-	if len(projects) == 0 {
-		syncFolder = sourceVolumePath
-	} else {
-		// if there is one or more projects in the devfile, get the first project and check its clonepath
-		project := projects[0]
-		// If clonepath does not exist source would be synced to $PROJECTS_ROOT/projectName
-		syncFolder = filepath.ToSlash(filepath.Join(sourceVolumePath, project.Name))
-
-		if project.ClonePath != "" {
-			if strings.HasPrefix(project.ClonePath, "/") {
-				return fmt.Errorf("the clonePath %s in the devfile project %s must be a relative path", project.ClonePath, project.Name)
-			}
-			if strings.Contains(project.ClonePath, "..") {
-				return fmt.Errorf("the clonePath %s in the devfile project %s cannot escape the value defined by $PROJECTS_ROOT. Please avoid using \"..\" in clonePath", project.ClonePath, project.Name)
-			}
-			// If clonepath exist source would be synced to $PROJECTS_ROOT/clonePath
-			syncFolder = filepath.ToSlash(filepath.Join(sourceVolumePath, project.ClonePath))
-		}
+	syncFolder, err := common.GetProjectSource(sourceVolumePath, projects)
+	if err != nil {
+		return err
 	}
 
 	container.Env = append(container.Env,
@@ -196,75 +265,101 @@ func getDeploymentSpec(deploySpecParams deploymentSpecParams) *appsv1.Deployment
 // getServiceSpec iterates through the devfile components and returns a ServiceSpec
 func getServiceSpec(devfileObj parser.DevfileObj, selectorLabels map[string]string, options common.DevfileOptions) (*corev1.ServiceSpec, error) {
 
-	var containerPorts []corev1.ContainerPort
-	portExposureMap, err := getPortExposure(devfileObj, options)
-	if err != nil {
-		return nil, err
-	}
-	containers, err := GetContainers(devfileObj, options)
+	containerComponents, err := devfileObj.Data.GetDevfileContainerComponents(options)
 	if err != nil {
 		return nil, err
 	}
-	for _, c := range containers {
-		for _, port := range c.Ports {
-			portExist := false
-			for _, entry := range containerPorts {
-				if entry.ContainerPort == port.ContainerPort {
-					portExist = true
-					break
-				}
-			}
-			// if Exposure == none, should not create a service for that port
-			if !portExist && portExposureMap[int(port.ContainerPort)] != v1.NoneEndpointExposure {
-				port.Name = fmt.Sprintf("port-%v", port.ContainerPort)
-				containerPorts = append(containerPorts, port)
-			}
-		}
+
+	var endpoints []v1.Endpoint
+	for _, comp := range containerComponents {
+		endpoints = append(endpoints, comp.Container.Endpoints...)
 	}
 
-	var svcPorts []corev1.ServicePort
-	for _, containerPort := range containerPorts {
-		svcPort := corev1.ServicePort{
+	return getServiceSpecFromEndpoints(endpoints, selectorLabels), nil
+}
 
-			Name:       containerPort.Name,
-			Port:       containerPort.ContainerPort,
-			TargetPort: intstr.FromInt(int(containerPort.ContainerPort)),
+// getServiceSpecFromEndpoints returns a cluster internal ServiceSpec exposing the endpoints
+func getServiceSpecFromEndpoints(endpoints []v1.Endpoint, selectorLabels map[string]string) *corev1.ServiceSpec {
+
+	var svcPorts []corev1.ServicePort
+	portNames := make(map[string]bool)
+	for _, endpoint := range getServiceEndpoints(endpoints) {
+		name := getPortName(endpoint.Name, endpoint.TargetPort)
+		// port names have to be unique in a service
+		for i := 1; portNames[name]; i++ {
+			suffix := fmt.Sprintf("-%d", i)
+			name = strings.TrimRight(util.TruncateString(getPortName(endpoint.Name, endpoint.TargetPort), portNameMaxLength-len(suffix)), "-") + suffix
 		}
-		svcPorts = append(svcPorts, svcPort)
+		portNames[name] = true
+
+		svcPorts = append(svcPorts, corev1.ServicePort{
+			Name:       name,
+			Port:       int32(endpoint.TargetPort),
+			TargetPort: intstr.FromInt(endpoint.TargetPort),
+			Protocol:   getPortProtocol(endpoint.Protocol),
+		})
 	}
+
 	svcSpec := &corev1.ServiceSpec{
+		Type:     corev1.ServiceTypeClusterIP,
 		Ports:    svcPorts,
 		Selector: selectorLabels,
 	}
 
-	return svcSpec, nil
+	return svcSpec
 }
 
-// getPortExposure iterates through all endpoints and returns the highest exposure level of all TargetPort.
-// exposure level: public > internal > none
-func getPortExposure(devfileObj parser.DevfileObj, options common.DevfileOptions) (map[int]v1.EndpointExposure, error) {
-	portExposureMap := make(map[int]v1.EndpointExposure)
-	containerComponents, err := devfileObj.Data.GetDevfileContainerComponents(options)
-	if err != nil {
-		return portExposureMap, err
+// getServiceEndpoints returns the endpoints a service should expose, in the order of their first definition.
+// Endpoints sharing a target port and a transport protocol are exposed once, with the highest exposure level among them.
+// exposure level: public > internal > none, endpoints with the exposure none are not exposed
+func getServiceEndpoints(endpoints []v1.Endpoint) []v1.Endpoint {
+	type portKey struct {
+		port     int
+		protocol corev1.Protocol
 	}
-	for _, comp := range containerComponents {
-		for _, endpoint := range comp.Container.Endpoints {
-			// if exposure=public, no need to check for existence
-			if endpoint.Exposure == v1.PublicEndpointExposure || endpoint.Exposure == "" {
-				portExposureMap[endpoint.TargetPort] = v1.PublicEndpointExposure
-			} else if exposure, exist := portExposureMap[endpoint.TargetPort]; exist {
-				// if a container has multiple identical ports with different exposure levels, save the highest level in the map
-				if endpoint.Exposure == v1.InternalEndpointExposure && exposure == v1.NoneEndpointExposure {
-					portExposureMap[endpoint.TargetPort] = v1.InternalEndpointExposure
-				}
-			} else {
-				portExposureMap[endpoint.TargetPort] = endpoint.Exposure
-			}
+
+	var keys []portKey
+	endpointMap := make(map[portKey]v1.Endpoint)
+	for _, endpoint := range endpoints {
+		key := portKey{port: endpoint.TargetPort, protocol: getPortProtocol(endpoint.Protocol)}
+		current, exist := endpointMap[key]
+		if !exist {
+			keys = append(keys, key)
+			endpointMap[key] = endpoint
+		} else if getExposureLevel(endpoint.Exposure) > getExposureLevel(current.Exposure) {
+			endpointMap[key] = endpoint
 		}
+	}
+
+	var serviceEndpoints []v1.Endpoint
+	for _, key := range keys {
+		if endpoint := endpointMap[key]; getExposureLevel(endpoint.Exposure) > getExposureLevel(v1.NoneEndpointExposure) {
+			serviceEndpoints = append(serviceEndpoints, endpoint)
+		}
+	}
+	return serviceEndpoints
+}
 
+// getResourceName returns the prefix followed by the name,
+// converted into a DNS-1123 name of at most 63 characters
+func getResourceName(prefix, name string) string {
+	if prefix != "" {
+		name = fmt.Sprintf("%s-%s", prefix, name)
+	}
+	name = util.GetDNS1123Name(strings.ToLower(name))
+	return strings.TrimRight(util.TruncateString(name, resourceNameMaxLength), "-")
+}
+
+// getExposureLevel returns the level of an endpoint exposure, an empty exposure defaults to public
+func getExposureLevel(exposure v1.EndpointExposure) int {
+	switch exposure {
+	case v1.NoneEndpointExposure:
+		return 0
+	case v1.InternalEndpointExposure:
+		return 1
+	default:
+		return 2
 	}
-	return portExposureMap, nil
 }
 
 // IngressSpecParams struct for function GenerateIngressSpec
@@ -273,12 +368,17 @@ func getPortExposure(devfileObj parser.DevfileObj, options common.DevfileOptions
 // portNumber is the target port of the ingress
 // Path is the path of the ingress
 // TLSSecretName is the target TLS Secret name of the ingress
+// PathType is the type of the ingress path, if empty it is not set on extensions/v1beta1 ingresses
+// and ImplementationSpecific on networking.k8s.io/v1 ingresses, which require a path type
+// IngressClassName is the name of the IngressClass of the ingress, the cluster default class if empty
 type IngressSpecParams struct {
-	ServiceName   string
-	IngressDomain string
-	PortNumber    intstr.IntOrString
-	TLSSecretName string
-	Path          string
+	ServiceName      string
+	IngressDomain    string
+	PortNumber       intstr.IntOrString
+	TLSSecretName    string
+	Path             string
+	PathType         networkingv1.PathType
+	IngressClassName string
 }
 
 // getIngressSpec gets an ingress spec
@@ -287,7 +387,13 @@ func getIngressSpec(ingressSpecParams IngressSpecParams) *extensionsv1.IngressSp
 	if ingressSpecParams.Path != "" {
 		path = ingressSpecParams.Path
 	}
+	var pathType *extensionsv1.PathType
+	if ingressSpecParams.PathType != "" {
+		extensionsPathType := extensionsv1.PathType(ingressSpecParams.PathType)
+		pathType = &extensionsPathType
+	}
 	ingressSpec := &extensionsv1.IngressSpec{
+		IngressClassName: getIngressClassName(ingressSpecParams.IngressClassName),
 		Rules: []extensionsv1.IngressRule{
 			{
 				Host: ingressSpecParams.IngressDomain,
@@ -295,7 +401,8 @@ func getIngressSpec(ingressSpecParams IngressSpecParams) *extensionsv1.IngressSp
 					HTTP: &extensionsv1.HTTPIngressRuleValue{
 						Paths: []extensionsv1.HTTPIngressPath{
 							{
-								Path: path,
+								Path:     path,
+								PathType: pathType,
 								Backend: extensionsv1.IngressBackend{
 									ServiceName: ingressSpecParams.ServiceName,
 									ServicePort: ingressSpecParams.PortNumber,
@@ -322,6 +429,141 @@ func getIngressSpec(ingressSpecParams IngressSpecParams) *extensionsv1.IngressSp
 	return ingressSpec
 }
 
+// getNetworkingV1IngressSpec gets a networking.k8s.io/v1 ingress spec
+func getNetworkingV1IngressSpec(ingressSpecParams IngressSpecParams) *networkingv1.IngressSpec {
+	path := "/"
+	if ingressSpecParams.Path != "" {
+		path = ingressSpecParams.Path
+	}
+	servicePort := networkingv1.ServiceBackendPort{}
+	if ingressSpecParams.PortNumber.Type == intstr.String {
+		servicePort.Name = ingressSpecParams.PortNumber.StrVal
+	} else {
+		servicePort.Number = ingressSpecParams.PortNumber.IntVal
+	}
+	pathType := getIngressPathType(ingressSpecParams.PathType)
+	ingressSpec := &networkingv1.IngressSpec{
+		IngressClassName: getIngressClassName(ingressSpecParams.IngressClassName),
+		Rules: []networkingv1.IngressRule{
+			{
+				Host: ingressSpecParams.IngressDomain,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{
+								Path:     path,
+								PathType: &pathType,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: ingressSpecParams.ServiceName,
+										Port: servicePort,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if len(ingressSpecParams.TLSSecretName) != 0 {
+		ingressSpec.TLS = []networkingv1.IngressTLS{
+			{
+				Hosts: []string{
+					ingressSpecParams.IngressDomain,
+				},
+				SecretName: ingressSpecParams.TLSSecretName,
+			},
+		}
+	}
+
+	return ingressSpec
+}
+
+// getIngressPathType returns the ingress path type, ImplementationSpecific if the path type is empty
+func getIngressPathType(pathType networkingv1.PathType) networkingv1.PathType {
+	if pathType == "" {
+		return networkingv1.PathTypeImplementationSpecific
+	}
+	return pathType
+}
+
+// getIngressClassName returns a reference to the ingress class name, nil if the name is empty
+func getIngressClassName(ingressClassName string) *string {
+	if ingressClassName == "" {
+		return nil
+	}
+	return &ingressClassName
+}
+
+// publicEndpoint is a public endpoint along with the name of its container component
+type publicEndpoint struct {
+	ComponentName string
+	Endpoint      v1.Endpoint
+}
+
+// getPublicEndpoints iterates through the container components and returns the endpoints with the exposure public
+// endpoints with a protocol that can't be routed over http, udp and sctp, are skipped
+func getPublicEndpoints(devfileObj parser.DevfileObj, options common.DevfileOptions) ([]publicEndpoint, error) {
+	var endpoints []publicEndpoint
+	containerComponents, err := devfileObj.Data.GetDevfileContainerComponents(options)
+	if err != nil {
+		return nil, err
+	}
+	for _, comp := range containerComponents {
+		for _, endpoint := range comp.Container.Endpoints {
+			if getExposureLevel(endpoint.Exposure) != getExposureLevel(v1.PublicEndpointExposure) {
+				continue
+			}
+			if getPortProtocol(endpoint.Protocol) != corev1.ProtocolTCP {
+				klog.V(4).Infof("skipping the endpoint %s of component %s, %s endpoints can't be exposed", endpoint.Name, comp.Name, endpoint.Protocol)
+				continue
+			}
+			endpoints = append(endpoints, publicEndpoint{
+				ComponentName: comp.Name,
+				Endpoint:      endpoint,
+			})
+		}
+	}
+	return endpoints, nil
+}
+
+// isSecureEndpoint checks if the endpoint is secure or has a secure protocol
+func isSecureEndpoint(endpoint v1.Endpoint) bool {
+	return endpoint.Secure || endpoint.Protocol == v1.HTTPSEndpointProtocol || endpoint.Protocol == v1.WSSEndpointProtocol
+}
+
+// hostTemplateData is the data available to a host template
+type hostTemplateData struct {
+	// Name is the name of the object meta
+	Name string
+	// Namespace is the namespace of the object meta
+	Namespace string
+	// Component is the name of the container component of the endpoint
+	Component string
+	// Endpoint is the name of the endpoint
+	Endpoint string
+	// Port is the target port of the endpoint
+	Port int
+}
+
+// getHost executes the host template with the data of the endpoint
+// an empty template returns an empty host
+func getHost(hostTemplate string, data hostTemplateData) (string, error) {
+	if hostTemplate == "" {
+		return "", nil
+	}
+	tmpl, err := template.New("host").Option("missingkey=error").Parse(hostTemplate)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse the host template %s", hostTemplate)
+	}
+	var host bytes.Buffer
+	if err = tmpl.Execute(&host, data); err != nil {
+		return "", errors.Wrapf(err, "failed to execute the host template %s", hostTemplate)
+	}
+	return host.String(), nil
+}
+
 // RouteSpecParams struct for function GenerateRouteSpec
 // serviceName is the name of the service for the target reference
 // portNumber is the target port of the ingress
@@ -377,6 +619,332 @@ func getPVCSpec(quantity resource.Quantity) *corev1.PersistentVolumeClaimSpec {
 	return pvcSpec
 }
 
+// devfilePod is a group of container components running in the same pod
+type devfilePod struct {
+	name       string
+	components []v1.Component
+	containers []corev1.Container
+}
+
+// getDevfilePods groups the container components and their containers by pod, the components with dedicatedPod set to true
+// are in their own pod named after the name prefix suffixed with the component name, the others are in the pod named after the name prefix
+func getDevfilePods(namePrefix string, containerComponents []v1.Component, containers []corev1.Container) []devfilePod {
+	sharedPod := devfilePod{name: namePrefix}
+	var dedicatedPods []devfilePod
+	for i, comp := range containerComponents {
+		if comp.Container.DedicatedPod {
+			dedicatedPods = append(dedicatedPods, devfilePod{
+				name:       getResourceName(namePrefix, comp.Name),
+				components: []v1.Component{comp},
+				containers: []corev1.Container{containers[i]},
+			})
+			continue
+		}
+		sharedPod.components = append(sharedPod.components, comp)
+		sharedPod.containers = append(sharedPod.containers, containers[i])
+	}
+
+	if len(sharedPod.components) == 0 {
+		return dedicatedPods
+	}
+	return append([]devfilePod{sharedPod}, dedicatedPods...)
+}
+
+// getVolumeMount gets the container volume mount of a devfile volume mount, mounted at /<name> if no path is set
+func getVolumeMount(volumeMount v1.VolumeMount) corev1.VolumeMount {
+	mountPath := volumeMount.Path
+	if mountPath == "" {
+		mountPath = "/" + volumeMount.Name
+	}
+	return corev1.VolumeMount{
+		Name:      volumeMount.Name,
+		MountPath: mountPath,
+	}
+}
+
+// addVolumeMounts adds the devfile volume mounts to the container
+func addVolumeMounts(container *corev1.Container, volumeMounts []v1.VolumeMount) {
+	for _, volumeMount := range volumeMounts {
+		container.VolumeMounts = append(container.VolumeMounts, getVolumeMount(volumeMount))
+	}
+}
+
+// getPVCVolume gets a pod volume backed by the pvc with the given claim name
+func getPVCVolume(name, claimName string) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+			},
+		},
+	}
+}
+
+// getVolumePVCs returns the pvcs of the volume components mounted by the pods, volumePods maps the volume names to the pods mounting them.
+// A volume mounted by several pods gets a ReadWriteMany pvc, an error is returned if the volume mount doesn't match a volume component
+// or if the ReadWriteOnce access mode is requested for a volume shared across pods
+func getVolumePVCs(objectMeta metav1.ObjectMeta, volumeComponents []v1.Component, volumePods map[string][]string, accessMode corev1.PersistentVolumeAccessMode) ([]corev1.PersistentVolumeClaim, error) {
+	volumeSizes := make(map[string]string)
+	for _, comp := range volumeComponents {
+		volumeSizes[comp.Name] = comp.Volume.Size
+	}
+	for volumeName := range volumePods {
+		if _, ok := volumeSizes[volumeName]; !ok {
+			return nil, fmt.Errorf("the volume %s mounted by the container components is not defined", volumeName)
+		}
+	}
+
+	var pvcs []corev1.PersistentVolumeClaim
+	for _, comp := range volumeComponents {
+		pods := volumePods[comp.Name]
+		if len(pods) == 0 {
+			continue
+		}
+
+		volumeAccessMode := accessMode
+		if len(pods) > 1 {
+			if accessMode == corev1.ReadWriteOnce {
+				return nil, fmt.Errorf("the volume %s is shared across the pods %s and cannot use the %s access mode", comp.Name, strings.Join(pods, ", "), corev1.ReadWriteOnce)
+			}
+			if volumeAccessMode == "" {
+				volumeAccessMode = corev1.ReadWriteMany
+			}
+		}
+
+		size := comp.Volume.Size
+		if size == "" {
+			size = DefaultVolumeSize
+		}
+		quantity, err := resource.ParseQuantity(size)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid size %s for volume %s", size, comp.Name)
+		}
+
+		pvcObjectMeta := *objectMeta.DeepCopy()
+		pvcObjectMeta.Name = getResourceName(objectMeta.Name, comp.Name)
+		pvc := GetPVC(PVCParams{
+			TypeMeta:   GetTypeMeta(pvcKind, coreV1),
+			ObjectMeta: pvcObjectMeta,
+			Quantity:   quantity,
+		})
+		if volumeAccessMode != "" {
+			pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{volumeAccessMode}
+		}
+		pvcs = append(pvcs, *pvc)
+	}
+
+	return pvcs, nil
+}
+
+// getEventCommands returns the exec and apply commands run by the event, in order. The composite commands are expanded recursively,
+// an error is returned if a command is not defined, is referenced in a cycle or is neither an exec, apply or composite command
+func getEventCommands(commands []v1.Command, eventName string, commandIDs []string) ([]v1.Command, error) {
+	var eventCommands []v1.Command
+	for _, commandID := range commandIDs {
+		plan, err := devfilecommands.ExpandCommand(commands, commandID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to expand the commands of the %s event", eventName)
+		}
+		for _, step := range plan.Steps() {
+			eventCommands = append(eventCommands, step.Command)
+		}
+	}
+	return eventCommands, nil
+}
+
+// eventContainer is a container running a command of a devfile event. The container of an apply command
+// of a kubernetes or openshift component is empty, the command applies the manifest of the component
+type eventContainer struct {
+	name      string
+	component v1.Component
+	container corev1.Container
+}
+
+// getEventContainers returns the containers running the commands of the event, the exec commands run in a copy of the container of their
+// component and the apply commands run the container of their component as is. If jobs is false only the exec commands are returned,
+// otherwise the apply commands of every event and the exec commands of the postStop event are returned.
+// The containers are named after the event and the command id, suffixed with a number if the name is already used by a component container
+// or by another command of the event
+func getEventContainers(devfileObj parser.DevfileObj, resourceParams ContainerResourceParams, eventName string, jobs bool) ([]eventContainer, error) {
+	var commandIDs []string
+	events, err := devfileObj.Data.GetEvents(common.DevfileOptions{})
+	if err != nil {
+		return nil, err
+	}
+	switch eventName {
+	case PreStartEvent:
+		commandIDs = events.PreStart
+	case PostStartEvent:
+		commandIDs = events.PostStart
+	case PreStopEvent:
+		commandIDs = events.PreStop
+	case PostStopEvent:
+		commandIDs = events.PostStop
+	}
+	if len(commandIDs) == 0 {
+		return nil, nil
+	}
+
+	commands, err := devfileObj.Data.GetCommands(common.DevfileOptions{})
+	if err != nil {
+		return nil, err
+	}
+	eventCommands, err := getEventCommands(commands, eventName, commandIDs)
+	if err != nil {
+		return nil, err
+	}
+	components, err := devfileObj.Data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return nil, err
+	}
+	containers, err := GetContainersWithResources(devfileObj, resourceParams, common.DevfileOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var eventContainers []eventContainer
+	containerNames := make(map[string]bool)
+	for _, container := range containers {
+		containerNames[container.Name] = true
+	}
+	for _, command := range eventCommands {
+		isExec := command.Exec != nil
+		if isExec && jobs && eventName != PostStopEvent || !isExec && !jobs {
+			continue
+		}
+
+		componentName := ""
+		if isExec {
+			componentName = command.Exec.Component
+		} else {
+			componentName = command.Apply.Component
+		}
+		var component *v1.Component
+		for i := range components {
+			if components[i].Name == componentName {
+				component = &components[i]
+				break
+			}
+		}
+		if component == nil {
+			return nil, fmt.Errorf("the component %s of the %s command %s is not defined", componentName, eventName, command.Id)
+		}
+		name := getEventContainerName(eventName, command.Id, containerNames)
+		if !isExec && (component.Kubernetes != nil || component.Openshift != nil) {
+			eventContainers = append(eventContainers, eventContainer{
+				name:      name,
+				component: *component,
+			})
+			continue
+		}
+		if component.Container == nil {
+			return nil, fmt.Errorf("the component %s of the %s command %s is not a container component", componentName, eventName, command.Id)
+		}
+
+		var container corev1.Container
+		for _, c := range containers {
+			if c.Name == componentName {
+				container = *c.DeepCopy()
+				break
+			}
+		}
+		container.Name = name
+		if isExec {
+			container.Command = []string{"/bin/sh", "-c", devfilecommands.GetExecScript(command.Exec.CommandLine, command.Exec.WorkingDir, command.Exec.Env)}
+			container.Args = nil
+			container.Ports = nil
+		}
+
+		eventContainers = append(eventContainers, eventContainer{
+			name:      name,
+			component: *component,
+			container: container,
+		})
+	}
+	return eventContainers, nil
+}
+
+// getEventContainerName returns a name made of the event and the command id that is not in the used names,
+// suffixed with a number if needed, and adds it to the used names
+func getEventContainerName(eventName, commandID string, usedNames map[string]bool) string {
+	base := getResourceName(eventName, commandID)
+	name := base
+	for i := 2; usedNames[name]; i++ {
+		suffix := fmt.Sprint(i)
+		name = getResourceName(util.TruncateString(base, resourceNameMaxLength-len(suffix)-1), suffix)
+	}
+	usedNames[name] = true
+	return name
+}
+
+// getManifestJobs returns the jobs of the manifest of the kubernetes or openshift component of an apply command of a devfile event,
+// with the labels and the namespace of the objectMeta. The jobs without a name are named after the objectMeta name.
+// An error is returned if the manifest defines a resource other than a job
+func getManifestJobs(ctx devfileCtx.DevfileCtx, objectMeta metav1.ObjectMeta, eventContainer eventContainer) ([]batchv1.Job, error) {
+	component := eventContainer.component
+	var k8sLikeComponent v1.K8sLikeComponent
+	if component.Kubernetes != nil {
+		k8sLikeComponent = component.Kubernetes.K8sLikeComponent
+	} else {
+		k8sLikeComponent = component.Openshift.K8sLikeComponent
+	}
+	content, err := getK8sLikeComponentContent(ctx, component.Name, k8sLikeComponent)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := decodeK8sManifest(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode the manifest of component %s", component.Name)
+	}
+
+	var jobs []batchv1.Job
+	for _, object := range objects {
+		if object.GetKind() != jobKind || object.GetAPIVersion() != jobAPIVersion {
+			return nil, fmt.Errorf("the manifest of component %s defines a %s %s, only %s %s resources can be applied by the devfile events",
+				component.Name, object.GetAPIVersion(), object.GetKind(), jobAPIVersion, jobKind)
+		}
+		applyObjectMeta(object, objectMeta)
+		if object.GetName() == "" {
+			object.SetName(objectMeta.Name)
+		}
+		var job batchv1.Job
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, &job); err != nil {
+			return nil, errors.Wrapf(err, "failed to convert the job %s of component %s", object.GetName(), component.Name)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// getJob gets a job running the event container, with the pvcs of the volumes mounted by its component named after the pvc name prefix
+func getJob(objectMeta metav1.ObjectMeta, pvcNamePrefix string, eventContainer eventContainer) *batchv1.Job {
+	container := eventContainer.container
+	container.Ports = nil
+	addVolumeMounts(&container, eventContainer.component.Container.VolumeMounts)
+
+	var volumes []corev1.Volume
+	for _, volumeMount := range eventContainer.component.Container.VolumeMounts {
+		volumes = append(volumes, getPVCVolume(volumeMount.Name, getResourceName(pvcNamePrefix, volumeMount.Name)))
+	}
+
+	backoffLimit := int32(0)
+	return &batchv1.Job{
+		TypeMeta:   GetTypeMeta(jobKind, jobAPIVersion),
+		ObjectMeta: objectMeta,
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    []corev1.Container{container},
+					Volumes:       volumes,
+				},
+			},
+		},
+	}
+}
+
 // BuildConfigSpecParams is a struct to create build config spec
 type BuildConfigSpecParams struct {
 	ImageStreamTagName string
@@ -409,3 +977,306 @@ func getBuildConfigSpec(buildConfigSpecParams BuildConfigSpecParams) *buildv1.Bu
 		},
 	}
 }
+
+// getK8sLikeComponentContent returns the manifest of a kubernetes or openshift component
+// an inlined manifest is returned as is, a manifest referenced by uri is fetched over http
+// or read through the filesystem of the devfile context, relative to the devfile location.
+// A local uri which is absolute or outside of the devfile directory is rejected
+func getK8sLikeComponentContent(ctx devfileCtx.DevfileCtx, componentName string, component v1.K8sLikeComponent) ([]byte, error) {
+	if component.Inlined != "" {
+		return []byte(component.Inlined), nil
+	}
+	if component.Uri == "" {
+		return nil, fmt.Errorf("component %s has neither an inlined manifest nor an uri", componentName)
+	}
+
+	uri := component.Uri
+	if !strings.HasPrefix(uri, "http://") && !strings.HasPrefix(uri, "https://") && ctx.GetURL() != "" {
+		// the devfile was read from an url, resolve the uri against it
+		base, err := url.Parse(ctx.GetURL())
+		if err != nil {
+			return nil, err
+		}
+		ref, err := url.Parse(uri)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uri %s in component %s: %v", uri, componentName, err)
+		}
+		uri = base.ResolveReference(ref).String()
+	}
+
+	if strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
+		content, err := util.HTTPGetRequest(util.HTTPRequestParams{URL: uri}, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the manifest of component %s from %s: %v", componentName, uri, err)
+		}
+		return content, nil
+	}
+
+	// a local manifest must be in the devfile directory, the devfile cannot read any file of the host
+	if path.IsAbs(filepath.ToSlash(uri)) || filepath.IsAbs(uri) {
+		return nil, fmt.Errorf("invalid uri %s in component %s, the path must be relative to the devfile directory", uri, componentName)
+	}
+	cleanURI := path.Clean(filepath.ToSlash(uri))
+	if cleanURI == ".." || strings.HasPrefix(cleanURI, "../") {
+		return nil, fmt.Errorf("invalid uri %s in component %s, the path is outside of the devfile directory", uri, componentName)
+	}
+	manifestPath := filepath.Join(filepath.Dir(ctx.GetAbsPath()), filepath.FromSlash(cleanURI))
+	fs := ctx.GetFs()
+	if fs == nil {
+		fs = filesystem.DefaultFs{}
+	}
+	if _, ok := fs.(filesystem.DefaultFs); ok {
+		// the symbolic links of the path must be in the devfile directory too
+		if err := checkInDevfileDir(filepath.Dir(ctx.GetAbsPath()), manifestPath); err != nil {
+			return nil, fmt.Errorf("invalid uri %s in component %s: %v", uri, componentName, err)
+		}
+	}
+	content, err := fs.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the manifest of component %s from %s: %v", componentName, manifestPath, err)
+	}
+	return content, nil
+}
+
+// checkInDevfileDir checks that the path, with its symbolic links resolved, is in the devfile directory
+func checkInDevfileDir(devfileDir, filePath string) error {
+	realDir, err := filepath.EvalSymlinks(devfileDir)
+	if err != nil {
+		return err
+	}
+	realPath, err := filepath.EvalSymlinks(filePath)
+	if err != nil {
+		return err
+	}
+	if relPath, err := filepath.Rel(realDir, realPath); err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("the path %s is outside of the devfile directory", filePath)
+	}
+	return nil
+}
+
+// decodeK8sManifest decodes a yaml or json manifest, that can hold multiple documents, into unstructured objects
+func decodeK8sManifest(content []byte) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), 4096)
+	for {
+		object := map[string]interface{}{}
+		err := decoder.Decode(&object)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// skip empty documents
+		if len(object) == 0 {
+			continue
+		}
+		unstructuredObject := &unstructured.Unstructured{Object: object}
+		if unstructuredObject.GetKind() == "" || unstructuredObject.GetAPIVersion() == "" {
+			return nil, fmt.Errorf("resource %q is missing the kind or the apiVersion", unstructuredObject.GetName())
+		}
+		objects = append(objects, unstructuredObject)
+	}
+	return objects, nil
+}
+
+// applyObjectMeta adds the labels and sets the namespace of the objectMeta on the object
+// labels from the objectMeta take precedence over the labels of the same key defined in the manifest
+func applyObjectMeta(object *unstructured.Unstructured, objectMeta metav1.ObjectMeta) {
+	if len(objectMeta.Labels) > 0 {
+		labels := object.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		for key, value := range objectMeta.Labels {
+			labels[key] = value
+		}
+		object.SetLabels(labels)
+	}
+	if objectMeta.Namespace != "" {
+		object.SetNamespace(objectMeta.Namespace)
+	}
+}
+
+const (
+	// buildSourceDir is the directory the git source is cloned to in the build jobs
+	buildSourceDir = "/workspace/source"
+	// buildWorkspaceVolume is the name of the volume shared by the containers of the build jobs
+	buildWorkspaceVolume = "workspace"
+	// buildDockerConfigVolume is the name of the volume of the docker config secret of the build jobs
+	buildDockerConfigVolume = "docker-config"
+	// kanikoDockerConfigDir is the directory of the docker config used by kaniko to push the images
+	kanikoDockerConfigDir = "/kaniko/.docker"
+)
+
+// getContextDockerfilePath returns the path of the Dockerfile relative to the build context,
+// if the Dockerfile is not in the build context, error out
+func getContextDockerfilePath(buildInfo DockerBuildInfo) (string, error) {
+	if buildInfo.BuildContext == "." {
+		return buildInfo.DockerfilePath, nil
+	}
+	if !strings.HasPrefix(buildInfo.DockerfilePath, buildInfo.BuildContext+"/") {
+		return "", fmt.Errorf("the Dockerfile %s is not in the build context %s", buildInfo.DockerfilePath, buildInfo.BuildContext)
+	}
+	return strings.TrimPrefix(buildInfo.DockerfilePath, buildInfo.BuildContext+"/"), nil
+}
+
+// getBuildArgs returns the build arguments of the devfile metadata attributes, sorted by name for a map of build arguments
+func getBuildArgs(metadataAttributes attributes.Attributes) ([]corev1.EnvVar, error) {
+	if !metadataAttributes.Exists(BuildArgsAttribute) {
+		return nil, nil
+	}
+
+	var buildArgs []corev1.EnvVar
+	var argsMap map[string]string
+	if err := metadataAttributes.GetInto(BuildArgsAttribute, &argsMap); err == nil {
+		for name, value := range argsMap {
+			buildArgs = append(buildArgs, corev1.EnvVar{Name: name, Value: value})
+		}
+		sort.Slice(buildArgs, func(i, j int) bool {
+			return buildArgs[i].Name < buildArgs[j].Name
+		})
+		return buildArgs, nil
+	}
+
+	var argsList []string
+	if err := metadataAttributes.GetInto(BuildArgsAttribute, &argsList); err != nil {
+		return nil, fmt.Errorf("invalid %s attribute, expected a map or a list of <name>=<value>", BuildArgsAttribute)
+	}
+	for _, arg := range argsList {
+		i := strings.Index(arg, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid build argument %q of the %s attribute, expected <name>=<value>", arg, BuildArgsAttribute)
+		}
+		buildArgs = append(buildArgs, corev1.EnvVar{Name: arg[:i], Value: arg[i+1:]})
+	}
+	return buildArgs, nil
+}
+
+// getProjectGitSource returns the git url and revision of the project with the given name, or of the first git project if the name is empty
+func getProjectGitSource(devfileObj parser.DevfileObj, projectName string) (string, string, error) {
+	projects, err := devfileObj.Data.GetProjects(common.DevfileOptions{})
+	if err != nil {
+		return "", "", err
+	}
+	for _, project := range projects {
+		if projectName != "" && project.Name != projectName {
+			continue
+		}
+
+		var gitSource *v1.GitLikeProjectSource
+		switch {
+		case project.Git != nil:
+			gitSource = &project.Git.GitLikeProjectSource
+		case project.Github != nil:
+			gitSource = &project.Github.GitLikeProjectSource
+		case projectName != "":
+			return "", "", fmt.Errorf("the project %s has no git source", projectName)
+		default:
+			continue
+		}
+		_, gitURL, gitRef, err := common.GetDefaultSource(*gitSource)
+		if err != nil {
+			return "", "", errors.Wrapf(err, "invalid git source of the project %s", project.Name)
+		}
+		return gitURL, gitRef, nil
+	}
+
+	if projectName != "" {
+		return "", "", &common.FieldNotFoundError{
+			Field: "project",
+			Name:  projectName,
+		}
+	}
+	return "", "", fmt.Errorf("the devfile has no project with a git source")
+}
+
+// getKanikoJob returns the job building the Dockerfile with kaniko, after cloning the git source with an init container
+func getKanikoJob(jobParams KanikoJobParams, buildInfo DockerBuildInfo, buildSource DockerBuildSource) *batchv1.Job {
+	gitImage := jobParams.GitImage
+	if gitImage == "" {
+		gitImage = DefaultGitImage
+	}
+	kanikoImage := jobParams.KanikoImage
+	if kanikoImage == "" {
+		kanikoImage = DefaultKanikoImage
+	}
+	workspaceMount := corev1.VolumeMount{
+		Name:      buildWorkspaceVolume,
+		MountPath: path.Dir(buildSourceDir),
+	}
+
+	cloneContainer := corev1.Container{
+		Name:    "git-clone",
+		Image:   gitImage,
+		Command: []string{"/bin/sh", "-c"},
+		Args:    []string{fmt.Sprintf(`git clone "$GIT_URL" %[1]s && if [ -n "$GIT_REF" ]; then git -C %[1]s checkout "$GIT_REF"; fi`, buildSourceDir)},
+		Env: []corev1.EnvVar{
+			{Name: "GIT_URL", Value: buildSource.GitURL},
+			{Name: "GIT_REF", Value: buildSource.GitRef},
+		},
+		VolumeMounts: []corev1.VolumeMount{workspaceMount},
+	}
+
+	args := []string{
+		"--dockerfile=" + path.Join(buildSourceDir, buildInfo.DockerfilePath),
+		"--context=dir://" + path.Join(buildSourceDir, buildInfo.BuildContext),
+	}
+	for _, buildArg := range buildInfo.BuildArgs {
+		args = append(args, fmt.Sprintf("--build-arg=%s=%s", buildArg.Name, buildArg.Value))
+	}
+	if jobParams.Image != "" {
+		args = append(args, "--destination="+jobParams.Image)
+	} else {
+		args = append(args, "--no-push")
+	}
+	kanikoContainer := corev1.Container{
+		Name:         "kaniko",
+		Image:        kanikoImage,
+		Args:         args,
+		VolumeMounts: []corev1.VolumeMount{workspaceMount},
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: buildWorkspaceVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	if jobParams.PushSecretName != "" {
+		kanikoContainer.VolumeMounts = append(kanikoContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      buildDockerConfigVolume,
+			MountPath: kanikoDockerConfigDir,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: buildDockerConfigVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: jobParams.PushSecretName,
+					Items: []corev1.KeyToPath{
+						{Key: corev1.DockerConfigJsonKey, Path: "config.json"},
+					},
+				},
+			},
+		})
+	}
+
+	backoffLimit := int32(0)
+	return &batchv1.Job{
+		TypeMeta:   GetTypeMeta(jobKind, jobAPIVersion),
+		ObjectMeta: jobParams.ObjectMeta,
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:  corev1.RestartPolicyNever,
+					InitContainers: []corev1.Container{cloneContainer},
+					Containers:     []corev1.Container{kanikoContainer},
+					Volumes:        volumes,
+				},
+			},
+		},
+	}
+}
@@ -15,6 +15,7 @@ import (
 	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
 
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -110,6 +111,7 @@ func TestConvertPorts(t *testing.T) {
 				{
 					Name:          endpointsNames[0],
 					ContainerPort: int32(endpointsPorts[0]),
+					Protocol:      corev1.ProtocolTCP,
 				},
 			},
 		},
@@ -129,10 +131,12 @@ func TestConvertPorts(t *testing.T) {
 				{
 					Name:          endpointsNames[0],
 					ContainerPort: int32(endpointsPorts[0]),
+					Protocol:      corev1.ProtocolTCP,
 				},
 				{
 					Name:          endpointsNames[1],
 					ContainerPort: int32(endpointsPorts[1]),
+					Protocol:      corev1.ProtocolTCP,
 				},
 			},
 		},
@@ -141,6 +145,33 @@ func TestConvertPorts(t *testing.T) {
 			endpoints: []v1.Endpoint{},
 			want:      []corev1.ContainerPort{},
 		},
+		{
+			name: "Case 4: Endpoints with udp and sctp protocols",
+			endpoints: []v1.Endpoint{
+				{
+					Name:       endpointsNames[0],
+					TargetPort: endpointsPorts[0],
+					Protocol:   v1.UDPEndpointProtocol,
+				},
+				{
+					Name:       endpointsNames[1],
+					TargetPort: endpointsPorts[1],
+					Protocol:   SCTPEndpointProtocol,
+				},
+			},
+			want: []corev1.ContainerPort{
+				{
+					Name:          endpointsNames[0],
+					ContainerPort: int32(endpointsPorts[0]),
+					Protocol:      corev1.ProtocolUDP,
+				},
+				{
+					Name:          endpointsNames[1],
+					ContainerPort: int32(endpointsPorts[1]),
+					Protocol:      corev1.ProtocolSCTP,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -162,7 +193,9 @@ func TestGetResourceReqs(t *testing.T) {
 	tests := []struct {
 		name      string
 		component v1.Component
+		defaults  ResourceRequirementsParams
 		want      corev1.ResourceRequirements
+		wantErr   bool
 	}{
 		{
 			name: "Case 1: One Endpoint",
@@ -201,11 +234,109 @@ func TestGetResourceReqs(t *testing.T) {
 			},
 			want: corev1.ResourceRequirements{},
 		},
+		{
+			name: "Case 4: Defaults with the devfile memoryLimit",
+			component: v1.Component{
+				Name: "testcomponent",
+				ComponentUnion: v1.ComponentUnion{
+					Container: &v1.ContainerComponent{
+						Container: v1.Container{
+							MemoryLimit: "1024Mi",
+						},
+					},
+				},
+			},
+			defaults: ResourceRequirementsParams{
+				MemoryRequest:           "512Mi",
+				MemoryLimit:             "2Gi",
+				CPURequest:              "100m",
+				CPULimit:                "1",
+				EphemeralStorageRequest: "1Gi",
+				EphemeralStorageLimit:   "2Gi",
+			},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory:           resource.MustParse("512Mi"),
+					corev1.ResourceCPU:              resource.MustParse("100m"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory:           quantity,
+					corev1.ResourceCPU:              resource.MustParse("1"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+				},
+			},
+		},
+		{
+			name: "Case 5: Invalid devfile memoryLimit",
+			component: v1.Component{
+				Name: "testcomponent",
+				ComponentUnion: v1.ComponentUnion{
+					Container: &v1.ContainerComponent{
+						Container: v1.Container{
+							MemoryLimit: "1024Mb",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 6: Invalid default cpu request",
+			component: v1.Component{
+				Name: "testcomponent",
+				ComponentUnion: v1.ComponentUnion{
+					Container: &v1.ContainerComponent{},
+				},
+			},
+			defaults: ResourceRequirementsParams{
+				CPURequest: "one",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 7: Memory request larger than the devfile memoryLimit",
+			component: v1.Component{
+				Name: "testcomponent",
+				ComponentUnion: v1.ComponentUnion{
+					Container: &v1.ContainerComponent{
+						Container: v1.Container{
+							MemoryLimit: "1024Mi",
+						},
+					},
+				},
+			},
+			defaults: ResourceRequirementsParams{
+				MemoryRequest: "2Gi",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 8: Cpu request larger than the cpu limit",
+			component: v1.Component{
+				Name: "testcomponent",
+				ComponentUnion: v1.ComponentUnion{
+					Container: &v1.ContainerComponent{},
+				},
+			},
+			defaults: ResourceRequirementsParams{
+				CPURequest: "2",
+				CPULimit:   "500m",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			req := getResourceReqs(tt.component)
+			req, err := getResourceReqs(tt.component, tt.defaults)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestGetResourceReqs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
 			if !reflect.DeepEqual(tt.want, req) {
 				t.Errorf("expected %v, wanted %v", req, tt.want)
 			}
@@ -213,6 +344,28 @@ func TestGetResourceReqs(t *testing.T) {
 	}
 }
 
+func TestMergeResourceRequirementsParams(t *testing.T) {
+	params := ResourceRequirementsParams{
+		MemoryLimit: "1Gi",
+		CPURequest:  "200m",
+	}
+	defaults := ResourceRequirementsParams{
+		MemoryRequest: "256Mi",
+		MemoryLimit:   "512Mi",
+		CPULimit:      "1",
+	}
+	want := ResourceRequirementsParams{
+		MemoryRequest: "256Mi",
+		MemoryLimit:   "1Gi",
+		CPURequest:    "200m",
+		CPULimit:      "1",
+	}
+
+	if got := mergeResourceRequirementsParams(params, defaults); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
 func TestAddSyncRootFolder(t *testing.T) {
 
 	tests := []struct {
@@ -643,9 +796,10 @@ func TestGetServiceSpec(t *testing.T) {
 			labels: map[string]string{},
 			wantPorts: []corev1.ServicePort{
 				{
-					Name:       "port-8080",
+					Name:       endpointNames[0],
 					Port:       8080,
 					TargetPort: intstr.FromInt(8080),
+					Protocol:   corev1.ProtocolTCP,
 				},
 			},
 			wantErr: false,
@@ -676,14 +830,16 @@ func TestGetServiceSpec(t *testing.T) {
 			},
 			wantPorts: []corev1.ServicePort{
 				{
-					Name:       "port-8080",
+					Name:       endpointNames[0],
 					Port:       8080,
 					TargetPort: intstr.FromInt(8080),
+					Protocol:   corev1.ProtocolTCP,
 				},
 				{
-					Name:       "port-9090",
+					Name:       endpointNames[2],
 					Port:       9090,
 					TargetPort: intstr.FromInt(9090),
+					Protocol:   corev1.ProtocolTCP,
 				},
 			},
 			wantErr: false,
@@ -727,9 +883,10 @@ func TestGetServiceSpec(t *testing.T) {
 			},
 			wantPorts: []corev1.ServicePort{
 				{
-					Name:       "port-9090",
+					Name:       endpointNames[2],
 					Port:       9090,
 					TargetPort: intstr.FromInt(9090),
+					Protocol:   corev1.ProtocolTCP,
 				},
 			},
 			wantErr: false,
@@ -739,6 +896,98 @@ func TestGetServiceSpec(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Case 4: endpoints with different exposures and protocols",
+			containerComponents: []v1.Component{
+				{
+					Name: "testcontainer1",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{
+							Endpoints: []v1.Endpoint{
+								{
+									Name:       "debug",
+									TargetPort: 5858,
+									Exposure:   v1.NoneEndpointExposure,
+								},
+								{
+									Name:       "metrics",
+									TargetPort: 9090,
+									Exposure:   v1.InternalEndpointExposure,
+								},
+								{
+									Name:       "dns",
+									TargetPort: 5353,
+									Protocol:   v1.UDPEndpointProtocol,
+								},
+							},
+						},
+					},
+				},
+			},
+			labels: map[string]string{
+				"component": "testcomponent",
+			},
+			wantPorts: []corev1.ServicePort{
+				{
+					Name:       "metrics",
+					Port:       9090,
+					TargetPort: intstr.FromInt(9090),
+					Protocol:   corev1.ProtocolTCP,
+				},
+				{
+					Name:       "dns",
+					Port:       5353,
+					TargetPort: intstr.FromInt(5353),
+					Protocol:   corev1.ProtocolUDP,
+				},
+			},
+		},
+		{
+			name: "Case 5: endpoints with the same port name",
+			containerComponents: []v1.Component{
+				{
+					Name: "testcontainer1",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{
+							Endpoints: []v1.Endpoint{
+								{
+									Name:       "http",
+									TargetPort: 8080,
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: "testcontainer2",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{
+							Endpoints: []v1.Endpoint{
+								{
+									Name:       "http",
+									TargetPort: 8081,
+								},
+							},
+						},
+					},
+				},
+			},
+			labels: map[string]string{},
+			wantPorts: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       8080,
+					TargetPort: intstr.FromInt(8080),
+					Protocol:   corev1.ProtocolTCP,
+				},
+				{
+					Name:       "http-1",
+					Port:       8081,
+					TargetPort: intstr.FromInt(8081),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -762,333 +1011,203 @@ func TestGetServiceSpec(t *testing.T) {
 				return
 			}
 
+			if serviceSpec.Type != corev1.ServiceTypeClusterIP {
+				t.Errorf("expected service type is %v, actual %v", corev1.ServiceTypeClusterIP, serviceSpec.Type)
+			}
 			if !reflect.DeepEqual(serviceSpec.Selector, tt.labels) {
 				t.Errorf("expected service selector is %v, actual %v", tt.labels, serviceSpec.Selector)
 			}
 			if len(serviceSpec.Ports) != len(tt.wantPorts) {
 				t.Errorf("expected service ports length is %v, actual %v", len(tt.wantPorts), len(serviceSpec.Ports))
 			} else {
-				for i := range serviceSpec.Ports {
-					if serviceSpec.Ports[i].Name != tt.wantPorts[i].Name {
-						t.Errorf("expected name %s, actual name %s", tt.wantPorts[i].Name, serviceSpec.Ports[i].Name)
-					}
-					if serviceSpec.Ports[i].Port != tt.wantPorts[i].Port {
-						t.Errorf("expected port number is %v, actual %v", tt.wantPorts[i].Port, serviceSpec.Ports[i].Port)
-					}
+				if !reflect.DeepEqual(serviceSpec.Ports, tt.wantPorts) {
+					t.Errorf("expected service ports %v, actual %v", tt.wantPorts, serviceSpec.Ports)
 				}
 			}
 		})
 	}
 }
 
-func TestGetPortExposure(t *testing.T) {
+func TestGetServiceEndpoints(t *testing.T) {
 	urlName := "testurl"
 	urlName2 := "testurl2"
 	tests := []struct {
-		name                string
-		containerComponents []v1.Component
-		filterOptions       common.DevfileOptions
-		wantMap             map[int]v1.EndpointExposure
-		wantErr             bool
+		name      string
+		endpoints []v1.Endpoint
+		want      []v1.Endpoint
 	}{
 		{
-			name: "Case 1: devfile has single container with single endpoint",
-			wantMap: map[int]v1.EndpointExposure{
-				8080: v1.PublicEndpointExposure,
+			name: "Case 1: single endpoint",
+			endpoints: []v1.Endpoint{
+				{
+					Name:       urlName,
+					TargetPort: 8080,
+					Exposure:   v1.PublicEndpointExposure,
+				},
 			},
-			containerComponents: []v1.Component{
+			want: []v1.Endpoint{
 				{
-					Name: "testcontainer1",
-					ComponentUnion: v1.ComponentUnion{
-						Container: &v1.ContainerComponent{
-							Container: v1.Container{
-								Image: "image",
-							},
-							Endpoints: []v1.Endpoint{
-								{
-									Name:       urlName,
-									TargetPort: 8080,
-									Exposure:   v1.PublicEndpointExposure,
-								},
-							},
-						},
-					},
+					Name:       urlName,
+					TargetPort: 8080,
+					Exposure:   v1.PublicEndpointExposure,
 				},
 			},
 		},
 		{
-			name:    "Case 2: devfile no endpoints",
-			wantMap: map[int]v1.EndpointExposure{},
-			containerComponents: []v1.Component{
+			name:      "Case 2: no endpoints",
+			endpoints: []v1.Endpoint{},
+		},
+		{
+			name: "Case 3: multiple endpoints with same port, 1 internal and 1 public, should keep public",
+			endpoints: []v1.Endpoint{
 				{
-					Name: "testcontainer1",
-					ComponentUnion: v1.ComponentUnion{
-						Container: &v1.ContainerComponent{
-							Container: v1.Container{
-								Image: "image",
-							},
-						},
-					},
+					Name:       urlName,
+					TargetPort: 8080,
+					Exposure:   v1.InternalEndpointExposure,
+				},
+				{
+					Name:       urlName2,
+					TargetPort: 8080,
+					Exposure:   v1.PublicEndpointExposure,
+					Path:       "/testpath",
+				},
+			},
+			want: []v1.Endpoint{
+				{
+					Name:       urlName2,
+					TargetPort: 8080,
+					Exposure:   v1.PublicEndpointExposure,
+					Path:       "/testpath",
 				},
 			},
 		},
 		{
-			name: "Case 3: devfile has multiple endpoints with same port, 1 public and 1 internal, should assign public",
-			wantMap: map[int]v1.EndpointExposure{
-				8080: v1.PublicEndpointExposure,
+			name: "Case 4: multiple endpoints with same port, 1 internal and 1 none, should keep internal",
+			endpoints: []v1.Endpoint{
+				{
+					Name:       urlName,
+					TargetPort: 8080,
+					Exposure:   v1.InternalEndpointExposure,
+				},
+				{
+					Name:       urlName2,
+					TargetPort: 8080,
+					Exposure:   v1.NoneEndpointExposure,
+				},
 			},
-			containerComponents: []v1.Component{
+			want: []v1.Endpoint{
 				{
-					Name: "testcontainer1",
-					ComponentUnion: v1.ComponentUnion{
-						Container: &v1.ContainerComponent{
-							Container: v1.Container{
-								Image: "image",
-							},
-							Endpoints: []v1.Endpoint{
-								{
-									Name:       urlName,
-									TargetPort: 8080,
-									Exposure:   v1.PublicEndpointExposure,
-								},
-								{
-									Name:       urlName,
-									TargetPort: 8080,
-									Exposure:   v1.InternalEndpointExposure,
-								},
-							},
-						},
-					},
+					Name:       urlName,
+					TargetPort: 8080,
+					Exposure:   v1.InternalEndpointExposure,
 				},
 			},
 		},
 		{
-			name: "Case 4: devfile has multiple endpoints with same port, 1 public and 1 none, should assign public",
-			wantMap: map[int]v1.EndpointExposure{
-				8080: v1.PublicEndpointExposure,
+			name: "Case 5: endpoints with exposure none are not exposed",
+			endpoints: []v1.Endpoint{
+				{
+					Name:       urlName,
+					TargetPort: 8080,
+				},
+				{
+					Name:       urlName2,
+					TargetPort: 3000,
+					Exposure:   v1.NoneEndpointExposure,
+				},
 			},
-			containerComponents: []v1.Component{
+			want: []v1.Endpoint{
 				{
-					Name: "testcontainer1",
-					ComponentUnion: v1.ComponentUnion{
-						Container: &v1.ContainerComponent{
-							Container: v1.Container{
-								Image: "image",
-							},
-							Endpoints: []v1.Endpoint{
-								{
-									Name:       urlName,
-									TargetPort: 8080,
-									Exposure:   v1.PublicEndpointExposure,
-								},
-								{
-									Name:       urlName,
-									TargetPort: 8080,
-									Exposure:   v1.NoneEndpointExposure,
-								},
-							},
-						},
-					},
+					Name:       urlName,
+					TargetPort: 8080,
 				},
 			},
 		},
 		{
-			name: "Case 5: devfile has multiple endpoints with same port, 1 internal and 1 none, should assign internal",
-			wantMap: map[int]v1.EndpointExposure{
-				8080: v1.InternalEndpointExposure,
+			name: "Case 6: same port with different transport protocols",
+			endpoints: []v1.Endpoint{
+				{
+					Name:       urlName,
+					TargetPort: 5353,
+					Protocol:   v1.TCPEndpointProtocol,
+				},
+				{
+					Name:       urlName2,
+					TargetPort: 5353,
+					Protocol:   v1.UDPEndpointProtocol,
+					Secure:     true,
+				},
+				{
+					Name:       "http",
+					TargetPort: 5353,
+					Protocol:   v1.HTTPEndpointProtocol,
+				},
 			},
-			containerComponents: []v1.Component{
+			want: []v1.Endpoint{
 				{
-					Name: "testcontainer1",
-					ComponentUnion: v1.ComponentUnion{
-						Container: &v1.ContainerComponent{
-							Container: v1.Container{
-								Image: "image",
-							},
-							Endpoints: []v1.Endpoint{
-								{
-									Name:       urlName,
-									TargetPort: 8080,
-									Exposure:   v1.InternalEndpointExposure,
-								},
-								{
-									Name:       urlName,
-									TargetPort: 8080,
-									Exposure:   v1.NoneEndpointExposure,
-								},
-							},
-						},
-					},
+					Name:       urlName,
+					TargetPort: 5353,
+					Protocol:   v1.TCPEndpointProtocol,
 				},
-			},
-		},
-		{
-			name: "Case 6: devfile has multiple endpoints with different port",
-			wantMap: map[int]v1.EndpointExposure{
-				8080: v1.PublicEndpointExposure,
-				9090: v1.InternalEndpointExposure,
-				3000: v1.NoneEndpointExposure,
-			},
-			containerComponents: []v1.Component{
 				{
-					Name: "testcontainer1",
-					ComponentUnion: v1.ComponentUnion{
-						Container: &v1.ContainerComponent{
-							Container: v1.Container{
-								Image: "image",
-							},
-							Endpoints: []v1.Endpoint{
-								{
-									Name:       urlName,
-									TargetPort: 8080,
-								},
-								{
-									Name:       urlName,
-									TargetPort: 3000,
-									Exposure:   v1.NoneEndpointExposure,
-								},
-							},
-						},
-					},
-				},
-				{
-					Name: "testcontainer2",
-					ComponentUnion: v1.ComponentUnion{
-						Container: &v1.ContainerComponent{
-							Container: v1.Container{
-								Image: "image",
-							},
-							Endpoints: []v1.Endpoint{
-								{
-									Name:       urlName2,
-									TargetPort: 9090,
-									Secure:     true,
-									Path:       "/testpath",
-									Exposure:   v1.InternalEndpointExposure,
-									Protocol:   v1.HTTPSEndpointProtocol,
-								},
-							},
-						},
-					},
+					Name:       urlName2,
+					TargetPort: 5353,
+					Protocol:   v1.UDPEndpointProtocol,
+					Secure:     true,
 				},
 			},
 		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints := getServiceEndpoints(tt.endpoints)
+			if !reflect.DeepEqual(endpoints, tt.want) {
+				t.Errorf("TestGetServiceEndpoints Expected: %v, got %v", tt.want, endpoints)
+			}
+		})
+	}
+
+}
+
+func TestGetPortName(t *testing.T) {
+	tests := []struct {
+		name         string
+		endpointName string
+		targetPort   int
+		want         string
+	}{
 		{
-			name: "Case 7: Filter components",
-			wantMap: map[int]v1.EndpointExposure{
-				8080: v1.PublicEndpointExposure,
-				3000: v1.NoneEndpointExposure,
-			},
-			containerComponents: []v1.Component{
-				{
-					Name: "testcontainer1",
-					Attributes: attributes.Attributes{}.FromStringMap(map[string]string{
-						"firstString": "firstStringValue",
-						"thirdString": "thirdStringValue",
-					}),
-					ComponentUnion: v1.ComponentUnion{
-						Container: &v1.ContainerComponent{
-							Container: v1.Container{
-								Image: "image",
-							},
-							Endpoints: []v1.Endpoint{
-								{
-									Name:       urlName,
-									TargetPort: 8080,
-								},
-								{
-									Name:       urlName,
-									TargetPort: 3000,
-									Exposure:   v1.NoneEndpointExposure,
-								},
-							},
-						},
-					},
-				},
-				{
-					Name: "testcontainer2",
-					ComponentUnion: v1.ComponentUnion{
-						Container: &v1.ContainerComponent{
-							Container: v1.Container{
-								Image: "image",
-							},
-							Endpoints: []v1.Endpoint{
-								{
-									Name:       urlName2,
-									TargetPort: 9090,
-									Secure:     true,
-									Path:       "/testpath",
-									Exposure:   v1.InternalEndpointExposure,
-									Protocol:   v1.HTTPSEndpointProtocol,
-								},
-							},
-						},
-					},
-				},
-			},
-			filterOptions: common.DevfileOptions{
-				Filter: map[string]interface{}{
-					"firstString": "firstStringValue",
-				},
-			},
+			name:         "Case 1: valid endpoint name",
+			endpointName: "http-8080",
+			targetPort:   8080,
+			want:         "http-8080",
 		},
 		{
-			name:    "Case 8: Wrong filter components",
-			wantMap: map[int]v1.EndpointExposure{},
-			containerComponents: []v1.Component{
-				{
-					Name: "testcontainer1",
-					Attributes: attributes.Attributes{}.FromStringMap(map[string]string{
-						"firstString": "firstStringValue",
-						"thirdString": "thirdStringValue",
-					}),
-					ComponentUnion: v1.ComponentUnion{
-						Container: &v1.ContainerComponent{
-							Container: v1.Container{
-								Image: "image",
-							},
-							Endpoints: []v1.Endpoint{
-								{
-									Name:       urlName,
-									TargetPort: 8080,
-								},
-								{
-									Name:       urlName,
-									TargetPort: 3000,
-									Exposure:   v1.NoneEndpointExposure,
-								},
-							},
-						},
-					},
-				},
-			},
-			filterOptions: common.DevfileOptions{
-				Filter: map[string]interface{}{
-					"firstStringWrong": "firstStringValue",
-				},
-			},
-			wantErr: false,
+			name:         "Case 2: endpoint name with invalid characters",
+			endpointName: "My_Endpoint.Name",
+			targetPort:   8080,
+			want:         "my-endpoint-nam",
+		},
+		{
+			name:         "Case 3: long endpoint name truncated before a dash",
+			endpointName: "debug-endpoint-port",
+			targetPort:   5858,
+			want:         "debug-endpoint",
+		},
+		{
+			name:         "Case 4: numeric endpoint name",
+			endpointName: "8080",
+			targetPort:   8080,
+			want:         "port-8080",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			devObj := parser.DevfileObj{
-				Data: &testingutil.TestDevfileData{
-					Components: tt.containerComponents,
-				},
+			if got := getPortName(tt.endpointName, tt.targetPort); got != tt.want {
+				t.Errorf("TestGetPortName Expected: %s, got %s", tt.want, got)
 			}
-			mapCreated, err := getPortExposure(devObj, tt.filterOptions)
-			if !tt.wantErr && err != nil {
-				t.Errorf("TestGetPortExposure unexpected error: %v", err)
-			} else if tt.wantErr && err == nil {
-				t.Errorf("TestGetPortExposure expected error but got nil")
-			} else if !reflect.DeepEqual(mapCreated, tt.wantMap) {
-				t.Errorf("TestGetPortExposure Expected: %v, got %v", tt.wantMap, mapCreated)
-			}
-
 		})
 	}
-
 }
 
 func TestGenerateIngressSpec(t *testing.T) {
@@ -1108,6 +1227,18 @@ func TestGenerateIngressSpec(t *testing.T) {
 				TLSSecretName: "testTLSSecret",
 			},
 		},
+		{
+			name: "2",
+			parameter: IngressSpecParams{
+				ServiceName:   "service1",
+				IngressDomain: "test.1.2.3.4.nip.io",
+				PortNumber: intstr.IntOrString{
+					IntVal: 8080,
+				},
+				TLSSecretName: "testTLSSecret",
+				PathType:      networkingv1.PathTypePrefix,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1115,6 +1246,11 @@ func TestGenerateIngressSpec(t *testing.T) {
 
 			ingressSpec := getIngressSpec(tt.parameter)
 
+			pathType := ingressSpec.Rules[0].HTTP.Paths[0].PathType
+			if (pathType == nil) != (tt.parameter.PathType == "") || (pathType != nil && string(*pathType) != string(tt.parameter.PathType)) {
+				t.Errorf("expected path type %q, actual %v", tt.parameter.PathType, pathType)
+			}
+
 			if ingressSpec.Rules[0].Host != tt.parameter.IngressDomain {
 				t.Errorf("expected %s, actual %s", tt.parameter.IngressDomain, ingressSpec.Rules[0].Host)
 			}
@@ -1135,6 +1271,69 @@ func TestGenerateIngressSpec(t *testing.T) {
 	}
 }
 
+func TestGetNetworkingV1IngressSpec(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		parameter IngressSpecParams
+		wantPort  networkingv1.ServiceBackendPort
+	}{
+		{
+			name: "Case 1: port number",
+			parameter: IngressSpecParams{
+				ServiceName:   "service1",
+				IngressDomain: "test.1.2.3.4.nip.io",
+				PortNumber:    intstr.FromInt(8080),
+				TLSSecretName: "testTLSSecret",
+			},
+			wantPort: networkingv1.ServiceBackendPort{
+				Number: 8080,
+			},
+		},
+		{
+			name: "Case 2: port name",
+			parameter: IngressSpecParams{
+				ServiceName:   "service1",
+				IngressDomain: "test.1.2.3.4.nip.io",
+				PortNumber:    intstr.FromString("http"),
+				Path:          "/test",
+			},
+			wantPort: networkingv1.ServiceBackendPort{
+				Name: "http",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			ingressSpec := getNetworkingV1IngressSpec(tt.parameter)
+
+			if ingressSpec.Rules[0].Host != tt.parameter.IngressDomain {
+				t.Errorf("expected %s, actual %s", tt.parameter.IngressDomain, ingressSpec.Rules[0].Host)
+			}
+
+			backend := ingressSpec.Rules[0].HTTP.Paths[0].Backend
+			if backend.Service.Port != tt.wantPort {
+				t.Errorf("expected %v, actual %v", tt.wantPort, backend.Service.Port)
+			}
+
+			if backend.Service.Name != tt.parameter.ServiceName {
+				t.Errorf("expected %s, actual %s", tt.parameter.ServiceName, backend.Service.Name)
+			}
+
+			if ingressSpec.Rules[0].HTTP.Paths[0].PathType == nil {
+				t.Errorf("expected a path type, actual nil")
+			}
+
+			if (len(ingressSpec.TLS) != 0) != (tt.parameter.TLSSecretName != "") {
+				t.Errorf("the ingress TLS %v does not match the TLS secret %s", ingressSpec.TLS, tt.parameter.TLSSecretName)
+			}
+
+		})
+	}
+}
+
 func TestGetRouteSpec(t *testing.T) {
 
 	tests := []struct {
@@ -1293,3 +1492,207 @@ func TestGetBuildConfigSpec(t *testing.T) {
 	}
 
 }
+
+func TestGetVolumeMount(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		volumeMount v1.VolumeMount
+		want        corev1.VolumeMount
+	}{
+		{
+			name:        "Case 1: Volume mount with path",
+			volumeMount: v1.VolumeMount{Name: "data", Path: "/var/data"},
+			want:        corev1.VolumeMount{Name: "data", MountPath: "/var/data"},
+		},
+		{
+			name:        "Case 2: Volume mount without path",
+			volumeMount: v1.VolumeMount{Name: "data"},
+			want:        corev1.VolumeMount{Name: "data", MountPath: "/data"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getVolumeMount(tt.volumeMount); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetEventCommands(t *testing.T) {
+
+	commands := []v1.Command{
+		{
+			Id: "build",
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{CommandLine: "make", Component: "runtime"},
+			},
+		},
+		{
+			Id: "deploy",
+			CommandUnion: v1.CommandUnion{
+				Apply: &v1.ApplyCommand{Component: "manifests"},
+			},
+		},
+		{
+			Id: "all",
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{Commands: []string{"Build", "nested"}},
+			},
+		},
+		{
+			Id: "nested",
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{Commands: []string{"deploy", "build"}},
+			},
+		},
+		{
+			Id: "cycle",
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{Commands: []string{"nested", "cycle"}},
+			},
+		},
+		{
+			Id: "dangling",
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{Commands: []string{"missing"}},
+			},
+		},
+		{
+			Id: "task",
+			CommandUnion: v1.CommandUnion{
+				VscodeTask: &v1.VscodeConfigurationCommand{},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		commandIDs []string
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name:       "Case 1: Nested composite commands",
+			commandIDs: []string{"all", "deploy"},
+			want:       []string{"build", "deploy", "build", "deploy"},
+		},
+		{
+			name:       "Case 2: Composite command cycle",
+			commandIDs: []string{"cycle"},
+			wantErr:    true,
+		},
+		{
+			name:       "Case 3: Dangling command reference",
+			commandIDs: []string{"dangling"},
+			wantErr:    true,
+		},
+		{
+			name:       "Case 4: Unsupported command type",
+			commandIDs: []string{"task"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventCommands, err := getEventCommands(commands, "postStart", tt.commandIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestGetEventCommands error: %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var got []string
+			for _, command := range eventCommands {
+				got = append(got, command.Id)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetEventContainerName(t *testing.T) {
+
+	usedNames := map[string]bool{"prestart-runtime": true}
+	tests := []struct {
+		name      string
+		commandID string
+		want      string
+	}{
+		{
+			name:      "Case 1: Unused name",
+			commandID: "install",
+			want:      "prestart-install",
+		},
+		{
+			name:      "Case 2: Name of a component container",
+			commandID: "runtime",
+			want:      "prestart-runtime-2",
+		},
+		{
+			name:      "Case 3: Name of another command",
+			commandID: "install",
+			want:      "prestart-install-2",
+		},
+		{
+			name:      "Case 4: Name of a suffixed command",
+			commandID: "install-2",
+			want:      "prestart-install-2-2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getEventContainerName(PreStartEvent, tt.commandID, usedNames); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetBuildArgs(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		buildArgs interface{}
+		want      []corev1.EnvVar
+		wantErr   bool
+	}{
+		{
+			name:      "case 1: map of build args sorted by name",
+			buildArgs: map[string]interface{}{"B": "2", "A": "1"},
+			want:      []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}},
+		},
+		{
+			name:      "case 2: list of build args in order",
+			buildArgs: []string{"B=2", "A=1=one", "C="},
+			want:      []corev1.EnvVar{{Name: "B", Value: "2"}, {Name: "A", Value: "1=one"}, {Name: "C", Value: ""}},
+		},
+		{
+			name:      "case 3: build arg without value",
+			buildArgs: []string{"A=1", "B"},
+			wantErr:   true,
+		},
+		{
+			name:      "case 4: invalid build args",
+			buildArgs: 12,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			metadataAttributes := attributes.Attributes{}.Put(BuildArgsAttribute, tt.buildArgs, &err)
+			if err != nil {
+				t.Fatalf("TestGetBuildArgs() unexpected error %v", err)
+			}
+			got, err := getBuildArgs(metadataAttributes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestGetBuildArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestGetBuildArgs() mismatch - got: %v, wanted: %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package parser
+
+import (
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	devfileCtx "github.com/devfile/library/pkg/devfile/parser/context"
+	"github.com/devfile/library/pkg/devfile/parser/data"
+	"github.com/devfile/library/pkg/devfile/validate"
+	"github.com/devfile/library/pkg/testingutil/filesystem"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+	"sigs.k8s.io/yaml"
+)
+
+// DevfileBuilder builds a new devfile at a path. The builder methods can be chained, the first error of a builder method
+// is returned by Build or Write and the following builder methods are ignored
+type DevfileBuilder struct {
+	path string
+	fs   filesystem.Filesystem
+	data data.DevfileData
+	err  error
+}
+
+// NewDevfile returns a builder of a new devfile at the path with the schema version
+func NewDevfile(path, schemaVersion string) *DevfileBuilder {
+	b := &DevfileBuilder{
+		path: path,
+		fs:   filesystem.DefaultFs{},
+	}
+	b.data, b.err = data.NewDevfileData(schemaVersion)
+	if b.err == nil {
+		b.data.SetSchemaVersion(schemaVersion)
+	}
+	return b
+}
+
+// WithFs sets the filesystem the devfile is written with
+func (b *DevfileBuilder) WithFs(fs filesystem.Filesystem) *DevfileBuilder {
+	b.fs = fs
+	return b
+}
+
+// WithMetadata sets the name and the version of the devfile
+func (b *DevfileBuilder) WithMetadata(name, version string) *DevfileBuilder {
+	if b.err == nil {
+		b.data.SetMetadata(name, version)
+	}
+	return b
+}
+
+// WithParent sets the parent of the devfile
+func (b *DevfileBuilder) WithParent(parent *v1.Parent) *DevfileBuilder {
+	if b.err == nil {
+		b.data.SetParent(parent)
+	}
+	return b
+}
+
+// AddContainer adds a container component with the name
+func (b *DevfileBuilder) AddContainer(name string, container v1.ContainerComponent) *DevfileBuilder {
+	return b.AddComponent(v1.Component{
+		Name: name,
+		ComponentUnion: v1.ComponentUnion{
+			Container: &container,
+		},
+	})
+}
+
+// AddVolume adds a volume component with the name and the size, the size is optional
+func (b *DevfileBuilder) AddVolume(name, size string) *DevfileBuilder {
+	return b.AddComponent(v1.Component{
+		Name: name,
+		ComponentUnion: v1.ComponentUnion{
+			Volume: &v1.VolumeComponent{
+				Volume: v1.Volume{
+					Size: size,
+				},
+			},
+		},
+	})
+}
+
+// AddComponent adds a component
+func (b *DevfileBuilder) AddComponent(component v1.Component) *DevfileBuilder {
+	if b.err == nil {
+		b.err = b.data.AddComponents([]v1.Component{component})
+	}
+	return b
+}
+
+// AddCommand adds a command
+func (b *DevfileBuilder) AddCommand(command v1.Command) *DevfileBuilder {
+	if b.err == nil {
+		b.err = b.data.AddCommands(command)
+	}
+	return b
+}
+
+// AddEvents adds the commands of the events
+func (b *DevfileBuilder) AddEvents(events v1.Events) *DevfileBuilder {
+	if b.err == nil {
+		b.err = b.data.AddEvents(events)
+	}
+	return b
+}
+
+// AddProject adds a project
+func (b *DevfileBuilder) AddProject(project v1.Project) *DevfileBuilder {
+	if b.err == nil {
+		b.err = b.data.AddProjects([]v1.Project{project})
+	}
+	return b
+}
+
+// AddStarterProject adds a starter project
+func (b *DevfileBuilder) AddStarterProject(starterProject v1.StarterProject) *DevfileBuilder {
+	if b.err == nil {
+		b.err = b.data.AddStarterProjects([]v1.StarterProject{starterProject})
+	}
+	return b
+}
+
+// Build returns the devfile object of the devfile, the devfile is validated against the JSON schema and the semantic rules
+// but is not written
+func (b *DevfileBuilder) Build() (DevfileObj, error) {
+	if b.err != nil {
+		return DevfileObj{}, b.err
+	}
+	devfileData, err := copyDevfileData(b.data)
+	if err != nil {
+		return DevfileObj{}, err
+	}
+	yamlData, err := yaml.Marshal(devfileData)
+	if err != nil {
+		return DevfileObj{}, errors.Wrapf(err, "failed to marshal devfile object into yaml")
+	}
+
+	ctx := devfileCtx.NewDevfileCtxWithFs(b.path, b.fs)
+	if err := ctx.SetAbsPath(); err != nil {
+		return DevfileObj{}, err
+	}
+	if err := ctx.SetDevfileContentFromBytes(yamlData); err != nil {
+		return DevfileObj{}, err
+	}
+	if err := ctx.PopulateFromRaw(); err != nil {
+		return DevfileObj{}, err
+	}
+	if err := ctx.Validate(); err != nil {
+		return DevfileObj{}, err
+	}
+	if err := validate.ValidateDevfileData(devfileData); err != nil {
+		return DevfileObj{}, err
+	}
+
+	return DevfileObj{
+		Ctx:  ctx,
+		Data: devfileData,
+	}, nil
+}
+
+// Write builds the devfile and writes it to its path. If a file already exists at the path, error out
+func (b *DevfileBuilder) Write() (DevfileObj, error) {
+	d, err := b.Build()
+	if err != nil {
+		return d, err
+	}
+	yamlData, err := yaml.Marshal(d.Data)
+	if err != nil {
+		return d, errors.Wrapf(err, "failed to marshal devfile object into yaml")
+	}
+	if err := d.createDevfile(yamlData); err != nil {
+		return d, err
+	}
+	klog.V(2).Infof("devfile created at: '%s'", d.Ctx.GetAbsPath())
+	return d, nil
+}
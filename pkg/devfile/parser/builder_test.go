@@ -0,0 +1,248 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/devfile/library/pkg/testingutil/filesystem"
+	"sigs.k8s.io/yaml"
+)
+
+func TestDevfileBuilder(t *testing.T) {
+
+	runCommand := v1.Command{
+		Id: "run",
+		CommandUnion: v1.CommandUnion{
+			Exec: &v1.ExecCommand{
+				LabeledCommand: v1.LabeledCommand{
+					BaseCommand: v1.BaseCommand{
+						Group: &v1.CommandGroup{Kind: v1.RunCommandGroupKind, IsDefault: true},
+					},
+				},
+				CommandLine: "npm start",
+				Component:   "runtime",
+			},
+		},
+	}
+	runtime := v1.ContainerComponent{
+		Container: v1.Container{
+			Image:        "quay.io/nodejs-12",
+			VolumeMounts: []v1.VolumeMount{{Name: "cache", Path: "/cache"}},
+		},
+		Endpoints: []v1.Endpoint{{Name: "http", TargetPort: 3000}},
+	}
+
+	tests := []struct {
+		name           string
+		builder        func(fs filesystem.Filesystem) *DevfileBuilder
+		existingFile   bool
+		wantErr        bool
+		wantAlreadyErr bool
+		wantDevfile    string
+	}{
+		{
+			name: "case 1: build and write a devfile",
+			builder: func(fs filesystem.Filesystem) *DevfileBuilder {
+				return NewDevfile("devfile.yaml", "2.0.0").
+					WithFs(fs).
+					WithMetadata("nodejs", "1.0.0").
+					AddContainer("runtime", runtime).
+					AddVolume("cache", "1Gi").
+					AddCommand(runCommand).
+					AddEvents(v1.Events{WorkspaceEvents: v1.WorkspaceEvents{PostStart: []string{"run"}}}).
+					AddStarterProject(v1.StarterProject{
+						Name: "starter",
+						ProjectSource: v1.ProjectSource{
+							Git: &v1.GitProjectSource{
+								GitLikeProjectSource: v1.GitLikeProjectSource{
+									Remotes: map[string]string{"origin": "https://github.com/odo-devfiles/nodejs-ex"},
+								},
+							},
+						},
+					})
+			},
+			wantDevfile: `commands:
+- exec:
+    commandLine: npm start
+    component: runtime
+    group:
+      isDefault: true
+      kind: run
+  id: run
+components:
+- container:
+    endpoints:
+    - name: http
+      targetPort: 3000
+    image: quay.io/nodejs-12
+    volumeMounts:
+    - name: cache
+      path: /cache
+  name: runtime
+- name: cache
+  volume:
+    size: 1Gi
+events:
+  postStart:
+  - run
+metadata:
+  name: nodejs
+  version: 1.0.0
+schemaVersion: 2.0.0
+starterProjects:
+- git:
+    remotes:
+      origin: https://github.com/odo-devfiles/nodejs-ex
+  name: starter
+`,
+		},
+		{
+			name: "case 2: unsupported schema version",
+			builder: func(fs filesystem.Filesystem) *DevfileBuilder {
+				return NewDevfile("devfile.yaml", "1.0.0").WithFs(fs).AddContainer("runtime", runtime)
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 3: duplicate component",
+			builder: func(fs filesystem.Filesystem) *DevfileBuilder {
+				return NewDevfile("devfile.yaml", "2.0.0").WithFs(fs).AddVolume("cache", "").AddVolume("cache", "1Gi")
+			},
+			wantErr:        true,
+			wantAlreadyErr: true,
+		},
+		{
+			name: "case 4: devfile not valid against the schema",
+			builder: func(fs filesystem.Filesystem) *DevfileBuilder {
+				return NewDevfile("devfile.yaml", "2.0.0").WithFs(fs).WithMetadata("nodejs", "latest")
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 5: command referring a missing component",
+			builder: func(fs filesystem.Filesystem) *DevfileBuilder {
+				return NewDevfile("devfile.yaml", "2.0.0").WithFs(fs).AddVolume("cache", "").AddCommand(runCommand)
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 6: devfile already exists",
+			builder: func(fs filesystem.Filesystem) *DevfileBuilder {
+				return NewDevfile("devfile.yaml", "2.0.0").WithFs(fs).WithMetadata("nodejs", "")
+			},
+			existingFile: true,
+			wantErr:      true,
+		},
+		{
+			name: "case 7: build and write a devfile with a kubernetes component",
+			builder: func(fs filesystem.Filesystem) *DevfileBuilder {
+				return NewDevfile("devfile.yaml", "2.0.0").
+					WithFs(fs).
+					WithMetadata("nodejs", "").
+					AddContainer("runtime", runtime).
+					AddVolume("cache", "").
+					AddComponent(v1.Component{
+						Name: "deployment",
+						ComponentUnion: v1.ComponentUnion{
+							Kubernetes: &v1.KubernetesComponent{
+								K8sLikeComponent: v1.K8sLikeComponent{
+									K8sLikeComponentLocation: v1.K8sLikeComponentLocation{
+										Uri: "deployment.yaml",
+									},
+								},
+							},
+						},
+					})
+			},
+			wantDevfile: `components:
+- container:
+    endpoints:
+    - name: http
+      targetPort: 3000
+    image: quay.io/nodejs-12
+    volumeMounts:
+    - name: cache
+      path: /cache
+  name: runtime
+- name: cache
+  volume: {}
+- kubernetes:
+    uri: deployment.yaml
+  name: deployment
+metadata:
+  name: nodejs
+schemaVersion: 2.0.0
+`,
+		},
+		{
+			name: "case 8: kubernetes component with the name of a container",
+			builder: func(fs filesystem.Filesystem) *DevfileBuilder {
+				return NewDevfile("devfile.yaml", "2.0.0").
+					WithFs(fs).
+					AddContainer("runtime", runtime).
+					AddComponent(v1.Component{
+						Name: "runtime",
+						ComponentUnion: v1.ComponentUnion{
+							Kubernetes: &v1.KubernetesComponent{},
+						},
+					})
+			},
+			wantErr:        true,
+			wantAlreadyErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := filesystem.NewFakeFs()
+			builder := tt.builder(fs)
+			if tt.existingFile {
+				d, err := builder.Build()
+				if err != nil {
+					t.Fatalf("TestDevfileBuilder() unexpected error %v", err)
+				}
+				if err := fs.WriteFile(d.Ctx.GetAbsPath(), []byte("schemaVersion: 2.0.0\n"), 0644); err != nil {
+					t.Fatalf("TestDevfileBuilder() unexpected error %v", err)
+				}
+			}
+
+			d, err := builder.Write()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TestDevfileBuilder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, ok := err.(*common.FieldAlreadyExistError); ok != tt.wantAlreadyErr {
+				t.Errorf("TestDevfileBuilder() expected a FieldAlreadyExistError: %v, got %T", tt.wantAlreadyErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			content, err := fs.ReadFile(d.Ctx.GetAbsPath())
+			if err != nil {
+				t.Fatalf("TestDevfileBuilder() unexpected error %v", err)
+			}
+			if string(content) != tt.wantDevfile {
+				t.Errorf("TestDevfileBuilder() devfile mismatch - wanted:\n%s\ngot:\n%s", tt.wantDevfile, content)
+			}
+
+			// the written devfile can be edited with the returned devfile object
+			if err := d.SetMemory("1Gi"); err != nil {
+				t.Errorf("TestDevfileBuilder() unexpected error %v", err)
+			}
+			content, err = fs.ReadFile(d.Ctx.GetAbsPath())
+			if err != nil {
+				t.Fatalf("TestDevfileBuilder() unexpected error %v", err)
+			}
+			var devfile map[string]interface{}
+			if err := yaml.Unmarshal(content, &devfile); err != nil {
+				t.Fatalf("TestDevfileBuilder() unexpected error %v", err)
+			}
+			container := devfile["components"].([]interface{})[0].(map[string]interface{})["container"].(map[string]interface{})
+			if !reflect.DeepEqual(container["memoryLimit"], "1Gi") {
+				t.Errorf("TestDevfileBuilder() memory mismatch - wanted: 1Gi, got: %v", container["memoryLimit"])
+			}
+		})
+	}
+}
@@ -28,69 +28,97 @@ func (d DevfileObj) SetMetadataName(name string) error {
 
 // AddEnvVars adds environment variables to all the components in a devfile
 func (d DevfileObj) AddEnvVars(otherList []v1.EnvVar) error {
-	components, err := d.Data.GetComponents(common.DevfileOptions{})
-	if err != nil {
-		return err
-	}
-	for _, component := range components {
-		if component.Container != nil {
-			component.Container.Env = Merge(component.Container.Env, otherList)
-			d.Data.UpdateComponent(component)
-		}
+	return d.AddEnvVarsWithOptions(common.DevfileOptions{}, otherList)
+}
+
+// AddEnvVarsToComponent adds environment variables to the container component with the given name
+func (d DevfileObj) AddEnvVarsToComponent(name string, otherList []v1.EnvVar) error {
+	return d.updateContainerComponents(name, common.DevfileOptions{}, addEnvVars(otherList))
+}
+
+// AddEnvVarsWithOptions adds environment variables to the container components matching the options
+func (d DevfileObj) AddEnvVarsWithOptions(options common.DevfileOptions, otherList []v1.EnvVar) error {
+	return d.updateContainerComponents("", options, addEnvVars(otherList))
+}
+
+func addEnvVars(otherList []v1.EnvVar) func(container *v1.ContainerComponent) error {
+	return func(container *v1.ContainerComponent) error {
+		container.Env = Merge(container.Env, otherList)
+		return nil
 	}
-	return d.WriteYamlDevfile()
 }
 
 // RemoveEnvVars removes the environment variables which have the keys from all the components in a devfile
-func (d DevfileObj) RemoveEnvVars(keys []string) (err error) {
-	components, err := d.Data.GetComponents(common.DevfileOptions{})
-	if err != nil {
+func (d DevfileObj) RemoveEnvVars(keys []string) error {
+	return d.RemoveEnvVarsWithOptions(common.DevfileOptions{}, keys)
+}
+
+// RemoveEnvVarsFromComponent removes the environment variables which have the keys from the container component with the given name
+func (d DevfileObj) RemoveEnvVarsFromComponent(name string, keys []string) error {
+	return d.updateContainerComponents(name, common.DevfileOptions{}, removeEnvVars(keys))
+}
+
+// RemoveEnvVarsWithOptions removes the environment variables which have the keys from the container components matching the options
+func (d DevfileObj) RemoveEnvVarsWithOptions(options common.DevfileOptions, keys []string) error {
+	return d.updateContainerComponents("", options, removeEnvVars(keys))
+}
+
+func removeEnvVars(keys []string) func(container *v1.ContainerComponent) error {
+	return func(container *v1.ContainerComponent) (err error) {
+		container.Env, err = RemoveEnvVarsFromList(container.Env, keys)
 		return err
 	}
-	for _, component := range components {
-		if component.Container != nil {
-			component.Container.Env, err = RemoveEnvVarsFromList(component.Container.Env, keys)
-			if err != nil {
-				return err
-			}
-			d.Data.UpdateComponent(component)
-		}
-	}
-	return d.WriteYamlDevfile()
 }
 
 // SetPorts converts ports to endpoints, adds to a devfile
+// the endpoints are named port-N-protocol, use AddEndpoint to add an endpoint with its exposure, path, secure and attributes
 func (d DevfileObj) SetPorts(ports ...string) error {
-	components, err := d.Data.GetComponents(common.DevfileOptions{})
+	return d.SetPortsWithOptions(common.DevfileOptions{}, ports...)
+}
+
+// SetPortsForComponent converts ports to endpoints, adds them to the container component with the given name
+func (d DevfileObj) SetPortsForComponent(name string, ports ...string) error {
+	endpoints, err := portsToEndpoints(ports...)
 	if err != nil {
 		return err
 	}
+	return d.updateContainerComponents(name, common.DevfileOptions{}, setEndpoints(endpoints))
+}
+
+// SetPortsWithOptions converts ports to endpoints, adds them to the container components matching the options
+func (d DevfileObj) SetPortsWithOptions(options common.DevfileOptions, ports ...string) error {
 	endpoints, err := portsToEndpoints(ports...)
 	if err != nil {
 		return err
 	}
-	for _, component := range components {
-		if component.Container != nil {
-			component.Container.Endpoints = addEndpoints(component.Container.Endpoints, endpoints)
-			d.Data.UpdateComponent(component)
-		}
+	return d.updateContainerComponents("", options, setEndpoints(endpoints))
+}
+
+func setEndpoints(endpoints []v1.Endpoint) func(container *v1.ContainerComponent) error {
+	return func(container *v1.ContainerComponent) error {
+		container.Endpoints = addEndpoints(container.Endpoints, endpoints)
+		return nil
 	}
-	return d.WriteYamlDevfile()
 }
 
-// RemovePorts removes all container endpoints from a devfile
+// RemovePorts removes all container endpoints from a devfile, use RemoveEndpoint to remove a single endpoint
 func (d DevfileObj) RemovePorts() error {
-	components, err := d.Data.GetComponents(common.DevfileOptions{})
-	if err != nil {
-		return err
-	}
-	for _, component := range components {
-		if component.Container != nil {
-			component.Container.Endpoints = []v1.Endpoint{}
-			d.Data.UpdateComponent(component)
-		}
-	}
-	return d.WriteYamlDevfile()
+	return d.RemovePortsWithOptions(common.DevfileOptions{})
+}
+
+// RemovePortsFromComponent removes the endpoints of the container component with the given name
+func (d DevfileObj) RemovePortsFromComponent(name string) error {
+	return d.updateContainerComponents(name, common.DevfileOptions{}, removeEndpoints)
+}
+
+// RemovePortsWithOptions removes the endpoints of the container components matching the options
+func (d DevfileObj) RemovePortsWithOptions(options common.DevfileOptions) error {
+	return d.updateContainerComponents("", options, removeEndpoints)
+}
+
+func removeEndpoints(container *v1.ContainerComponent) error {
+	container.Endpoints = []v1.Endpoint{}
+	return nil
 }
 
 // HasPorts checks if a devfile contains container endpoints
@@ -111,17 +139,24 @@ func (d DevfileObj) HasPorts() bool {
 
 // SetMemory sets memoryLimit in devfile container
 func (d DevfileObj) SetMemory(memory string) error {
-	components, err := d.Data.GetComponents(common.DevfileOptions{})
-	if err != nil {
-		return err
-	}
-	for _, component := range components {
-		if component.Container != nil {
-			component.Container.MemoryLimit = memory
-			d.Data.UpdateComponent(component)
-		}
+	return d.SetMemoryWithOptions(common.DevfileOptions{}, memory)
+}
+
+// SetMemoryForComponent sets memoryLimit in the container component with the given name
+func (d DevfileObj) SetMemoryForComponent(name string, memory string) error {
+	return d.updateContainerComponents(name, common.DevfileOptions{}, setMemory(memory))
+}
+
+// SetMemoryWithOptions sets memoryLimit in the container components matching the options
+func (d DevfileObj) SetMemoryWithOptions(options common.DevfileOptions, memory string) error {
+	return d.updateContainerComponents("", options, setMemory(memory))
+}
+
+func setMemory(memory string) func(container *v1.ContainerComponent) error {
+	return func(container *v1.ContainerComponent) error {
+		container.MemoryLimit = memory
+		return nil
 	}
-	return d.WriteYamlDevfile()
 }
 
 // GetMemory gets memoryLimit from devfile container
@@ -141,6 +176,48 @@ func (d DevfileObj) GetMemory() string {
 	return ""
 }
 
+// GetMemoryByComponent gets the memoryLimit of the devfile container components matching the options, by component name
+// the components without memoryLimit are reported with an empty value
+func (d DevfileObj) GetMemoryByComponent(options common.DevfileOptions) (map[string]string, error) {
+	components, err := d.Data.GetDevfileContainerComponents(options)
+	if err != nil {
+		return nil, err
+	}
+	memory := make(map[string]string)
+	for _, component := range components {
+		memory[component.Name] = component.Container.MemoryLimit
+	}
+	return memory, nil
+}
+
+// updateContainerComponents updates the container components with the given name, or matching the options if the name is empty,
+// and writes the devfile. If no container component has the given name, error out
+func (d DevfileObj) updateContainerComponents(name string, options common.DevfileOptions, update func(container *v1.ContainerComponent) error) error {
+	if name != "" {
+		options = common.DevfileOptions{Query: common.Equals(common.NameKey, name)}
+	}
+	components, err := d.Data.GetDevfileContainerComponents(options)
+	if err != nil {
+		return err
+	}
+	if name != "" && len(components) == 0 {
+		return &common.FieldNotFoundError{
+			Field: "container component",
+			Name:  name,
+		}
+	}
+
+	for _, component := range components {
+		if err := update(component.Container); err != nil {
+			return err
+		}
+		if err := d.Data.UpdateComponent(component); err != nil {
+			return err
+		}
+	}
+	return d.WriteYamlDevfile()
+}
+
 // GetMetadataName gets metadata name from a devfile
 func (d DevfileObj) GetMetadataName() string {
 	return d.Data.GetMetadata().Name
@@ -233,7 +310,7 @@ func GetContainerPortsFromStrings(ports []string) ([]corev1.ContainerPort, error
 }
 
 // RemoveEnvVarsFromList removes the env variables based on the keys provided
-// and returns a new EnvVarList
+// and returns a new EnvVarList. If some keys are not found, error out with all the missing keys
 func RemoveEnvVarsFromList(envVarList []v1.EnvVar, keys []string) ([]v1.EnvVar, error) {
 	// convert the envVarList map to an array to easily search for env var(s)
 	// to remove from the component
@@ -244,11 +321,17 @@ func RemoveEnvVarsFromList(envVarList []v1.EnvVar, keys []string) ([]v1.EnvVar,
 
 	// now check if the environment variable(s) requested for removal exists in
 	// the env vars set for the component by odo
+	var missingKeys []string
 	for _, key := range keys {
-		if !InArray(envVarListArray, key) {
-			return nil, fmt.Errorf("unable to find environment variable %s in the component", key)
+		if !InArray(envVarListArray, key) && !InArray(missingKeys, key) {
+			missingKeys = append(missingKeys, key)
 		}
 	}
+	if len(missingKeys) == 1 {
+		return nil, fmt.Errorf("unable to find environment variable %s in the component", missingKeys[0])
+	} else if len(missingKeys) > 1 {
+		return nil, fmt.Errorf("unable to find environment variables %s in the component", strings.Join(missingKeys, ", "))
+	}
 
 	// finally, let's remove the environment variables(s) requested by the user
 	newEnvVarList := []v1.EnvVar{}
@@ -263,25 +346,28 @@ func RemoveEnvVarsFromList(envVarList []v1.EnvVar, keys []string) ([]v1.EnvVar,
 }
 
 // Merge merges the other EnvVarlist with keeping last value for duplicate EnvVars
-// and returns a new EnvVarList
+// and returns a new EnvVarList. The order of the env vars is preserved: an env var keeps the position
+// of its first occurrence and the new env vars are appended in the order of the other list
 func Merge(original []v1.EnvVar, other []v1.EnvVar) []v1.EnvVar {
 
-	var dedupNewEvl []v1.EnvVar
-	newEvl := append(original, other...)
-	uniqueMap := make(map[string]string)
-	// last value will be kept in case of duplicate env vars
-	for _, envVar := range newEvl {
-		uniqueMap[envVar.Name] = envVar.Value
-	}
-
-	for key, value := range uniqueMap {
-		dedupNewEvl = append(dedupNewEvl, v1.EnvVar{
-			Name:  key,
-			Value: value,
-		})
+	var mergedEvl []v1.EnvVar
+	indexes := make(map[string]int)
+	for _, envVarList := range [][]v1.EnvVar{original, other} {
+		for _, envVar := range envVarList {
+			// last value will be kept in case of duplicate env vars
+			if index, ok := indexes[envVar.Name]; ok {
+				mergedEvl[index].Value = envVar.Value
+				continue
+			}
+			indexes[envVar.Name] = len(mergedEvl)
+			mergedEvl = append(mergedEvl, v1.EnvVar{
+				Name:  envVar.Name,
+				Value: envVar.Value,
+			})
+		}
 	}
 
-	return dedupNewEvl
+	return mergedEvl
 
 }
 
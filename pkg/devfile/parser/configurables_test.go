@@ -7,6 +7,7 @@ import (
 	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
 	devfileCtx "github.com/devfile/library/pkg/devfile/parser/context"
 	v2 "github.com/devfile/library/pkg/devfile/parser/data/v2"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 	"github.com/devfile/library/pkg/testingutil/filesystem"
 	"github.com/kylelemons/godebug/pretty"
 )
@@ -216,3 +217,248 @@ func testDevfileObj(fs filesystem.Filesystem) DevfileObj {
 		},
 	}
 }
+
+func TestComponentConfigurables(t *testing.T) {
+
+	fs := filesystem.NewFakeFs()
+
+	tests := []struct {
+		name            string
+		update          func(d DevfileObj) error
+		wantEnv         map[string][]v1.EnvVar
+		wantEndpoints   map[string][]v1.Endpoint
+		wantMemory      map[string]string
+		wantErr         bool
+		wantNotFoundErr bool
+	}{
+		{
+			name: "case 1: update all the container components",
+			update: func(d DevfileObj) error {
+				if err := d.AddEnvVars([]v1.EnvVar{{Name: "PORT", Value: "3000"}}); err != nil {
+					return err
+				}
+				return d.SetMemory("1Gi")
+			},
+			wantEnv: map[string][]v1.EnvVar{
+				"runtime":      {{Name: "PORT", Value: "3000"}},
+				"loadbalancer": {{Name: "PORT", Value: "3000"}},
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime": {{Name: "port-3030", TargetPort: 3000}},
+			},
+			wantMemory: map[string]string{"runtime": "1Gi", "loadbalancer": "1Gi"},
+		},
+		{
+			name: "case 2: update the container component with the given name",
+			update: func(d DevfileObj) error {
+				if err := d.AddEnvVarsToComponent("loadbalancer", []v1.EnvVar{{Name: "PORT", Value: "8080"}}); err != nil {
+					return err
+				}
+				if err := d.SetPortsForComponent("loadbalancer", "8080"); err != nil {
+					return err
+				}
+				if err := d.RemovePortsFromComponent("runtime"); err != nil {
+					return err
+				}
+				return d.SetMemoryForComponent("runtime", "512Mi")
+			},
+			wantEnv: map[string][]v1.EnvVar{
+				"loadbalancer": {{Name: "PORT", Value: "8080"}},
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime":      {},
+				"loadbalancer": {{Name: "port-8080-tcp", TargetPort: 8080, Protocol: "tcp"}},
+			},
+			wantMemory: map[string]string{"runtime": "512Mi", "loadbalancer": ""},
+		},
+		{
+			name: "case 3: update the container components matching the options",
+			update: func(d DevfileObj) error {
+				options := common.DevfileOptions{
+					Query: common.HasPrefix(common.NameKey, "run"),
+				}
+				if err := d.AddEnvVarsWithOptions(options, []v1.EnvVar{{Name: "DEBUG", Value: "true"}, {Name: "MODE", Value: "dev"}}); err != nil {
+					return err
+				}
+				if err := d.RemoveEnvVarsWithOptions(options, []string{"DEBUG"}); err != nil {
+					return err
+				}
+				if err := d.SetPortsWithOptions(options, "9229/udp"); err != nil {
+					return err
+				}
+				return d.SetMemoryWithOptions(options, "2Gi")
+			},
+			wantEnv: map[string][]v1.EnvVar{
+				"runtime": {{Name: "MODE", Value: "dev"}},
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime": {{Name: "port-3030", TargetPort: 3000}, {Name: "port-9229-udp", TargetPort: 9229, Protocol: "udp"}},
+			},
+			wantMemory: map[string]string{"runtime": "2Gi", "loadbalancer": ""},
+		},
+		{
+			name: "case 4: update a missing container component",
+			update: func(d DevfileObj) error {
+				return d.SetMemoryForComponent("database", "1Gi")
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime": {{Name: "port-3030", TargetPort: 3000}},
+			},
+			wantMemory:      map[string]string{"runtime": "", "loadbalancer": ""},
+			wantErr:         true,
+			wantNotFoundErr: true,
+		},
+		{
+			name: "case 5: remove a missing env var from a container component",
+			update: func(d DevfileObj) error {
+				return d.RemoveEnvVarsFromComponent("runtime", []string{"PORT"})
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime": {{Name: "port-3030", TargetPort: 3000}},
+			},
+			wantMemory: map[string]string{"runtime": "", "loadbalancer": ""},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testDevfileObj(fs)
+
+			err := tt.update(d)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestComponentConfigurables() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, ok := err.(*common.FieldNotFoundError); ok != tt.wantNotFoundErr {
+				t.Errorf("TestComponentConfigurables() expected a FieldNotFoundError: %v, got %T", tt.wantNotFoundErr, err)
+			}
+
+			components, err := d.Data.GetDevfileContainerComponents(common.DevfileOptions{})
+			if err != nil {
+				t.Errorf("TestComponentConfigurables() unexpected error %v", err)
+			}
+			for _, component := range components {
+				if !reflect.DeepEqual(component.Container.Env, tt.wantEnv[component.Name]) {
+					t.Errorf("TestComponentConfigurables() env mismatch for %s - wanted: %v, got: %v", component.Name, tt.wantEnv[component.Name], component.Container.Env)
+				}
+				if !reflect.DeepEqual(component.Container.Endpoints, tt.wantEndpoints[component.Name]) {
+					t.Errorf("TestComponentConfigurables() endpoints mismatch for %s - wanted: %v, got: %v", component.Name, tt.wantEndpoints[component.Name], component.Container.Endpoints)
+				}
+			}
+
+			memory, err := d.GetMemoryByComponent(common.DevfileOptions{})
+			if err != nil {
+				t.Errorf("TestComponentConfigurables() unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(memory, tt.wantMemory) {
+				t.Errorf("TestComponentConfigurables() memory mismatch - wanted: %v, got: %v", tt.wantMemory, memory)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		original []v1.EnvVar
+		other    []v1.EnvVar
+		want     []v1.EnvVar
+	}{
+		{
+			name:     "case 1: append new env vars in order",
+			original: []v1.EnvVar{{Name: "FOO", Value: "foo"}, {Name: "BAR", Value: "$(FOO)/bar"}},
+			other:    []v1.EnvVar{{Name: "ZOO", Value: "zoo"}, {Name: "BAZ", Value: "$(BAR)/baz"}, {Name: "ABC", Value: "abc"}},
+			want: []v1.EnvVar{
+				{Name: "FOO", Value: "foo"},
+				{Name: "BAR", Value: "$(FOO)/bar"},
+				{Name: "ZOO", Value: "zoo"},
+				{Name: "BAZ", Value: "$(BAR)/baz"},
+				{Name: "ABC", Value: "abc"},
+			},
+		},
+		{
+			name:     "case 2: update existing env vars in place",
+			original: []v1.EnvVar{{Name: "FOO", Value: "foo"}, {Name: "BAR", Value: "bar"}, {Name: "BAZ", Value: "baz"}},
+			other:    []v1.EnvVar{{Name: "BAR", Value: "new-bar"}, {Name: "QUX", Value: "qux"}},
+			want: []v1.EnvVar{
+				{Name: "FOO", Value: "foo"},
+				{Name: "BAR", Value: "new-bar"},
+				{Name: "BAZ", Value: "baz"},
+				{Name: "QUX", Value: "qux"},
+			},
+		},
+		{
+			name:     "case 3: keep the first position and the last value of duplicate env vars",
+			original: []v1.EnvVar{{Name: "FOO", Value: "foo"}, {Name: "FOO", Value: "foo2"}},
+			other:    []v1.EnvVar{{Name: "PORT", Value: "3003"}, {Name: "DEBUG", Value: "true"}, {Name: "PORT", Value: "4342"}},
+			want: []v1.EnvVar{
+				{Name: "FOO", Value: "foo2"},
+				{Name: "PORT", Value: "4342"},
+				{Name: "DEBUG", Value: "true"},
+			},
+		},
+		{
+			name: "case 4: merge empty lists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := append([]v1.EnvVar(nil), tt.original...)
+
+			// the merge must give the same result on every run
+			for i := 0; i < 20; i++ {
+				got := Merge(tt.original, tt.other)
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Fatalf("TestMerge() mismatch - wanted: %v, got: %v", tt.want, got)
+				}
+			}
+			if !reflect.DeepEqual(tt.original, original) {
+				t.Errorf("TestMerge() the original list was modified - got: %v", tt.original)
+			}
+		})
+	}
+}
+
+func TestRemoveEnvVarsFromList(t *testing.T) {
+
+	envVars := []v1.EnvVar{{Name: "FOO", Value: "foo"}, {Name: "BAR", Value: "bar"}, {Name: "BAZ", Value: "baz"}}
+
+	tests := []struct {
+		name    string
+		keys    []string
+		want    []v1.EnvVar
+		wantErr string
+	}{
+		{
+			name: "case 1: remove env vars",
+			keys: []string{"BAZ", "FOO"},
+			want: []v1.EnvVar{{Name: "BAR", Value: "bar"}},
+		},
+		{
+			name:    "case 2: remove a missing env var",
+			keys:    []string{"FOO", "DEBUG"},
+			wantErr: "unable to find environment variable DEBUG in the component",
+		},
+		{
+			name:    "case 3: remove several missing env vars",
+			keys:    []string{"PORT", "FOO", "DEBUG", "PORT"},
+			wantErr: "unable to find environment variables PORT, DEBUG in the component",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RemoveEnvVarsFromList(envVars, tt.keys)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("TestRemoveEnvVarsFromList() unexpected error %v", err)
+			} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("TestRemoveEnvVarsFromList() error = %v, wanted %q", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestRemoveEnvVarsFromList() mismatch - wanted: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
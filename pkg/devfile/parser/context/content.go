@@ -2,6 +2,8 @@ package parser
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"unicode"
 
 	"github.com/devfile/library/pkg/util"
@@ -63,6 +65,7 @@ func (d *DevfileCtx) SetDevfileContent() error {
 		if err != nil {
 			return errors.Wrapf(err, "failed to read devfile from path '%s'", d.absPath)
 		}
+		d.SetContentHash(data)
 	}
 
 	// set devfile content
@@ -86,3 +89,27 @@ func (d *DevfileCtx) SetDevfileContentFromBytes(data []byte) error {
 func (d *DevfileCtx) GetDevfileContent() []byte {
 	return d.rawContent
 }
+
+// GetContentHash returns the hash of the devfile content read from or last written to the devfile path,
+// an empty string if the devfile was not read from a path
+func (d *DevfileCtx) GetContentHash() string {
+	if d.contentHash == nil {
+		return ""
+	}
+	return *d.contentHash
+}
+
+// SetContentHash sets the hash of the devfile content read from or written to the devfile path. The hash is shared by
+// the copies of a context created with a constructor, the hash of a zero context is only set on the context itself
+func (d *DevfileCtx) SetContentHash(content []byte) {
+	if d.contentHash == nil {
+		d.contentHash = new(string)
+	}
+	*d.contentHash = HashContent(content)
+}
+
+// HashContent returns the hash of the devfile content
+func HashContent(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
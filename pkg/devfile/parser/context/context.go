@@ -31,20 +31,34 @@ type DevfileCtx struct {
 
 	// filesystem for devfile
 	fs filesystem.Filesystem
+
+	// hash of the devfile content read from or last written to the devfile path, shared by the copies of the context
+	contentHash *string
 }
 
 // NewDevfileCtx returns a new DevfileCtx type object
 func NewDevfileCtx(path string) DevfileCtx {
 	return DevfileCtx{
-		relPath: path,
-		fs:      filesystem.DefaultFs{},
+		relPath:     path,
+		fs:          filesystem.DefaultFs{},
+		contentHash: new(string),
+	}
+}
+
+// NewDevfileCtxWithFs returns a new DevfileCtx type object reading and writing the devfile with the filesystem
+func NewDevfileCtxWithFs(path string, fs filesystem.Filesystem) DevfileCtx {
+	return DevfileCtx{
+		relPath:     path,
+		fs:          fs,
+		contentHash: new(string),
 	}
 }
 
 // NewURLDevfileCtx returns a new DevfileCtx type object
 func NewURLDevfileCtx(url string) DevfileCtx {
 	return DevfileCtx{
-		url: url,
+		url:         url,
+		contentHash: new(string),
 	}
 }
 
@@ -106,6 +120,11 @@ func (d *DevfileCtx) GetAbsPath() string {
 	return d.absPath
 }
 
+// GetURL returns the url of the devfile, if the devfile was read from an url
+func (d *DevfileCtx) GetURL() string {
+	return d.url
+}
+
 // SetAbsPath sets absolute file path for devfile
 func (d *DevfileCtx) SetAbsPath() (err error) {
 	// Set devfile absolute path
@@ -18,34 +18,48 @@ type DevfileData interface {
 	SetParent(parent *v1.Parent)
 
 	// event related methods
-	GetEvents() v1.Events
+	GetEvents(common.DevfileOptions) (v1.Events, error)
 	AddEvents(events v1.Events) error
 	UpdateEvents(postStart, postStop, preStart, preStop []string)
 
 	// component related methods
 	GetComponents(common.DevfileOptions) ([]v1.Component, error)
 	AddComponents(components []v1.Component) error
-	UpdateComponent(component v1.Component)
+	UpdateComponent(component v1.Component) error
+	UpsertComponent(component v1.Component)
+	DeleteComponent(name string, componentType v1.ComponentType) error
+	RenameComponent(oldName, newName string, componentType v1.ComponentType) error
 
 	// project related methods
 	GetProjects(common.DevfileOptions) ([]v1.Project, error)
 	AddProjects(projects []v1.Project) error
-	UpdateProject(project v1.Project)
+	UpdateProject(project v1.Project) error
+	UpsertProject(project v1.Project)
+	DeleteProject(name string) error
+	RenameProject(oldName, newName string) error
 
 	// starter projects related commands
 	GetStarterProjects(common.DevfileOptions) ([]v1.StarterProject, error)
 	AddStarterProjects(projects []v1.StarterProject) error
-	UpdateStarterProject(project v1.StarterProject)
+	UpdateStarterProject(project v1.StarterProject) error
+	UpsertStarterProject(project v1.StarterProject)
+	DeleteStarterProject(name string) error
+	RenameStarterProject(oldName, newName string) error
 
 	// command related methods
 	GetCommands(common.DevfileOptions) ([]v1.Command, error)
 	AddCommands(commands ...v1.Command) error
-	UpdateCommand(command v1.Command)
+	UpdateCommand(command v1.Command) error
+	UpsertCommand(command v1.Command)
+	DeleteCommand(id string) error
+	RenameCommand(oldID, newID string) error
+	GetCommandsByGroup(groupKind v1.CommandGroupKind, options common.DevfileOptions) ([]v1.Command, error)
+	GetDefaultCommand(groupKind v1.CommandGroupKind, options common.DevfileOptions) (v1.Command, error)
 
 	// volume related methods
 	AddVolume(volume v1.Component, path string) error
 	DeleteVolume(name string) error
-	GetVolumeMountPath(name string) (string, error)
+	GetVolumeMountPath(name string, options common.DevfileOptions) (string, error)
 
 	//utils
 	GetDevfileContainerComponents(common.DevfileOptions) ([]v1.Component, error)
@@ -8,14 +8,11 @@ import (
 )
 
 // GetCommands returns the slice of Command objects parsed from the Devfile
+// the command ids are returned in lowercase, whether the commands are filtered or not
 func (d *DevfileV2) GetCommands(options common.DevfileOptions) ([]v1.Command, error) {
-	if len(options.Filter) == 0 {
-		return d.Commands, nil
-	}
-
 	var commands []v1.Command
 	for _, command := range d.Commands {
-		filterIn, err := common.FilterDevfileObject(command.Attributes, options)
+		filterIn, err := common.FilterDevfileCommand(command, options)
 		if err != nil {
 			return nil, err
 		}
@@ -29,6 +26,25 @@ func (d *DevfileV2) GetCommands(options common.DevfileOptions) ([]v1.Command, er
 	return commands, nil
 }
 
+// GetCommandsByGroup returns the commands of the group kind
+func (d *DevfileV2) GetCommandsByGroup(groupKind v1.CommandGroupKind, options common.DevfileOptions) ([]v1.Command, error) {
+	commands, err := d.GetCommands(options)
+	if err != nil {
+		return nil, err
+	}
+	return common.GetCommandsByGroup(commands, groupKind), nil
+}
+
+// GetDefaultCommand returns the default command of the group kind
+// if the group has several commands, exactly one of them must be marked as default
+func (d *DevfileV2) GetDefaultCommand(groupKind v1.CommandGroupKind, options common.DevfileOptions) (v1.Command, error) {
+	commands, err := d.GetCommands(options)
+	if err != nil {
+		return v1.Command{}, err
+	}
+	return common.GetDefaultCommand(commands, groupKind)
+}
+
 // AddCommands adds the slice of Command objects to the Devfile's commands
 // if a command is already defined, error out
 func (d *DevfileV2) AddCommands(commands ...v1.Command) error {
@@ -39,7 +55,7 @@ func (d *DevfileV2) AddCommands(commands ...v1.Command) error {
 
 	for _, command := range commands {
 		for _, devfileCommand := range devfileCommands {
-			if command.Id == devfileCommand.Id {
+			if strings.ToLower(command.Id) == devfileCommand.Id {
 				return &common.FieldAlreadyExistError{Name: command.Id, Field: "command"}
 			}
 		}
@@ -49,11 +65,106 @@ func (d *DevfileV2) AddCommands(commands ...v1.Command) error {
 }
 
 // UpdateCommand updates the command with the given id
-func (d *DevfileV2) UpdateCommand(command v1.Command) {
+// if the command is not found, error out
+func (d *DevfileV2) UpdateCommand(command v1.Command) error {
+	found := false
 	for i := range d.Commands {
 		if strings.ToLower(d.Commands[i].Id) == strings.ToLower(command.Id) {
 			d.Commands[i] = command
 			d.Commands[i].Id = strings.ToLower(d.Commands[i].Id)
+			found = true
+		}
+	}
+	if !found {
+		return &common.FieldNotFoundError{
+			Field: "command",
+			Name:  command.Id,
+		}
+	}
+	return nil
+}
+
+// UpsertCommand updates the command with the given id, or adds it if it is not defined
+func (d *DevfileV2) UpsertCommand(command v1.Command) {
+	if err := d.UpdateCommand(command); err != nil {
+		d.Commands = append(d.Commands, command)
+	}
+}
+
+// DeleteCommand removes the command with the given id from the devfile
+// the command id is removed from the composite commands and the events too
+func (d *DevfileV2) DeleteCommand(id string) error {
+	found := false
+	var commands []v1.Command
+	for _, command := range d.Commands {
+		if strings.ToLower(command.Id) == strings.ToLower(id) {
+			found = true
+			continue
 		}
+		commands = append(commands, command)
+	}
+	if !found {
+		return &common.FieldNotFoundError{
+			Field: "command",
+			Name:  id,
+		}
+	}
+
+	d.Commands = commands
+	d.updateCommandReferences(id, nil)
+	return nil
+}
+
+// RenameCommand renames the command with the given id, the references to the command in the composite commands
+// and in the events are renamed too
+func (d *DevfileV2) RenameCommand(oldID, newID string) error {
+	index := -1
+	for i, command := range d.Commands {
+		if strings.ToLower(command.Id) == strings.ToLower(oldID) {
+			index = i
+		} else if strings.ToLower(command.Id) == strings.ToLower(newID) {
+			return &common.FieldAlreadyExistError{
+				Field: "command",
+				Name:  newID,
+			}
+		}
+	}
+	if index == -1 {
+		return &common.FieldNotFoundError{
+			Field: "command",
+			Name:  oldID,
+		}
+	}
+
+	d.Commands[index].Id = newID
+	d.updateCommandReferences(oldID, []string{newID})
+	return nil
+}
+
+// updateCommandReferences replaces the references to the command id in the composite commands and in the events
+// with the new ids
+func (d *DevfileV2) updateCommandReferences(id string, newIDs []string) {
+	update := func(ids []string) []string {
+		var updated []string
+		for _, commandID := range ids {
+			if strings.ToLower(commandID) == strings.ToLower(id) {
+				updated = append(updated, newIDs...)
+			} else {
+				updated = append(updated, commandID)
+			}
+		}
+		return updated
+	}
+
+	for _, command := range d.Commands {
+		if command.Composite != nil {
+			command.Composite.Commands = update(command.Composite.Commands)
+		}
+	}
+	if d.Events != nil {
+		d.Events.PreStart = update(d.Events.PreStart)
+		d.Events.PostStart = update(d.Events.PostStart)
+		d.Events.PreStop = update(d.Events.PreStop)
+		d.Events.PostStop = update(d.Events.PostStop)
 	}
 }
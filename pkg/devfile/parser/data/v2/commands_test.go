@@ -235,6 +235,7 @@ func TestDevfile200_UpdateCommands(t *testing.T) {
 		name            string
 		currentCommands []v1.Command
 		newCommand      v1.Command
+		wantErr         bool
 	}{
 		{
 			name: "case 1: update the command",
@@ -263,6 +264,24 @@ func TestDevfile200_UpdateCommands(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "case 2: fail to update a missing command",
+			currentCommands: []v1.Command{
+				{
+					Id: "command2",
+					CommandUnion: v1.CommandUnion{
+						Composite: &v1.CompositeCommand{},
+					},
+				},
+			},
+			newCommand: v1.Command{
+				Id: "command1",
+				CommandUnion: v1.CommandUnion{
+					Exec: &v1.ExecCommand{},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -276,7 +295,16 @@ func TestDevfile200_UpdateCommands(t *testing.T) {
 				},
 			}
 
-			d.UpdateCommand(tt.newCommand)
+			err := d.UpdateCommand(tt.newCommand)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestDevfile200_UpdateCommands() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if _, ok := err.(*common.FieldNotFoundError); !ok {
+					t.Errorf("TestDevfile200_UpdateCommands() expected a FieldNotFoundError, got %T", err)
+				}
+				return
+			}
 
 			commands, err := d.GetCommands(common.DevfileOptions{})
 			if err != nil {
@@ -300,3 +328,250 @@ func TestDevfile200_UpdateCommands(t *testing.T) {
 		})
 	}
 }
+
+func TestDevfile200_GetDefaultCommand(t *testing.T) {
+
+	getCommand := func(id string, kind v1.CommandGroupKind, isDefault bool) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{
+					LabeledCommand: v1.LabeledCommand{
+						BaseCommand: v1.BaseCommand{
+							Group: &v1.CommandGroup{
+								Kind:      kind,
+								IsDefault: isDefault,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	d := &DevfileV2{
+		v1.Devfile{
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Commands: []v1.Command{
+						getCommand("build1", v1.BuildCommandGroupKind, false),
+						getCommand("build2", v1.BuildCommandGroupKind, true),
+						getCommand("run1", v1.RunCommandGroupKind, false),
+					},
+				},
+			},
+		},
+	}
+
+	buildCommands, err := d.GetCommandsByGroup(v1.BuildCommandGroupKind, common.DevfileOptions{})
+	if err != nil {
+		t.Errorf("TestDevfile200_GetDefaultCommand() unexpected error %v", err)
+		return
+	}
+	if len(buildCommands) != 2 {
+		t.Errorf("TestDevfile200_GetDefaultCommand() build commands length mismatch - wanted 2, got %d", len(buildCommands))
+	}
+
+	command, err := d.GetDefaultCommand(v1.BuildCommandGroupKind, common.DevfileOptions{})
+	if err != nil || command.Id != "build2" {
+		t.Errorf("TestDevfile200_GetDefaultCommand() wanted build2, got %s, error %v", command.Id, err)
+	}
+	command, err = d.GetDefaultCommand(v1.RunCommandGroupKind, common.DevfileOptions{})
+	if err != nil || command.Id != "run1" {
+		t.Errorf("TestDevfile200_GetDefaultCommand() wanted run1, got %s, error %v", command.Id, err)
+	}
+	if _, err = d.GetDefaultCommand(v1.TestCommandGroupKind, common.DevfileOptions{}); err == nil {
+		t.Errorf("TestDevfile200_GetDefaultCommand() expected an error for the test group")
+	}
+}
+
+func getCommandReferencesTestDevfile() *DevfileV2 {
+	return &DevfileV2{
+		v1.Devfile{
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Commands: []v1.Command{
+						{
+							Id: "Build",
+							CommandUnion: v1.CommandUnion{
+								Exec: &v1.ExecCommand{Component: "runtime"},
+							},
+						},
+						{
+							Id: "run",
+							CommandUnion: v1.CommandUnion{
+								Exec: &v1.ExecCommand{Component: "runtime"},
+							},
+						},
+						{
+							Id: "buildandrun",
+							CommandUnion: v1.CommandUnion{
+								Composite: &v1.CompositeCommand{
+									Commands: []string{"build", "run"},
+								},
+							},
+						},
+					},
+					Events: &v1.Events{
+						WorkspaceEvents: v1.WorkspaceEvents{
+							PreStart:  []string{"Build"},
+							PostStart: []string{"run", "buildandrun"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDevfile200_DeleteAndRenameCommand(t *testing.T) {
+	tests := []struct {
+		name          string
+		update        func(d *DevfileV2) error
+		wantCommands  []string
+		wantComposite []string
+		wantEvents    v1.WorkspaceEvents
+		wantErr       bool
+	}{
+		{
+			name: "case 1: delete a command referenced by a composite command and an event",
+			update: func(d *DevfileV2) error {
+				return d.DeleteCommand("build")
+			},
+			wantCommands:  []string{"run", "buildandrun"},
+			wantComposite: []string{"run"},
+			wantEvents: v1.WorkspaceEvents{
+				PostStart: []string{"run", "buildandrun"},
+			},
+		},
+		{
+			name: "case 2: delete a missing command",
+			update: func(d *DevfileV2) error {
+				return d.DeleteCommand("test")
+			},
+			wantCommands:  []string{"Build", "run", "buildandrun"},
+			wantComposite: []string{"build", "run"},
+			wantEvents: v1.WorkspaceEvents{
+				PreStart:  []string{"Build"},
+				PostStart: []string{"run", "buildandrun"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 3: rename a command referenced by a composite command and events",
+			update: func(d *DevfileV2) error {
+				return d.RenameCommand("BUILD", "compile")
+			},
+			wantCommands:  []string{"compile", "run", "buildandrun"},
+			wantComposite: []string{"compile", "run"},
+			wantEvents: v1.WorkspaceEvents{
+				PreStart:  []string{"compile"},
+				PostStart: []string{"run", "buildandrun"},
+			},
+		},
+		{
+			name: "case 4: rename a command with the id of another command",
+			update: func(d *DevfileV2) error {
+				return d.RenameCommand("run", "build")
+			},
+			wantCommands:  []string{"Build", "run", "buildandrun"},
+			wantComposite: []string{"build", "run"},
+			wantEvents: v1.WorkspaceEvents{
+				PreStart:  []string{"Build"},
+				PostStart: []string{"run", "buildandrun"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 5: rename a command changing the case of its id",
+			update: func(d *DevfileV2) error {
+				return d.RenameCommand("Build", "build")
+			},
+			wantCommands:  []string{"build", "run", "buildandrun"},
+			wantComposite: []string{"build", "run"},
+			wantEvents: v1.WorkspaceEvents{
+				PreStart:  []string{"build"},
+				PostStart: []string{"run", "buildandrun"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := getCommandReferencesTestDevfile()
+
+			err := tt.update(d)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestDevfile200_DeleteAndRenameCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var commands []string
+			var composite []string
+			for _, command := range d.Commands {
+				commands = append(commands, command.Id)
+				if command.Composite != nil {
+					composite = command.Composite.Commands
+				}
+			}
+			if !reflect.DeepEqual(commands, tt.wantCommands) {
+				t.Errorf("TestDevfile200_DeleteAndRenameCommand() commands mismatch - wanted: %v, got: %v", tt.wantCommands, commands)
+			}
+			if !reflect.DeepEqual(composite, tt.wantComposite) {
+				t.Errorf("TestDevfile200_DeleteAndRenameCommand() composite command mismatch - wanted: %v, got: %v", tt.wantComposite, composite)
+			}
+			if !reflect.DeepEqual(d.Events.WorkspaceEvents, tt.wantEvents) {
+				t.Errorf("TestDevfile200_DeleteAndRenameCommand() events mismatch - wanted: %v, got: %v", tt.wantEvents, d.Events.WorkspaceEvents)
+			}
+		})
+	}
+}
+
+func TestDevfile200_UpsertCommand(t *testing.T) {
+	d := &DevfileV2{
+		v1.Devfile{
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Commands: []v1.Command{
+						{
+							Id: "build",
+							CommandUnion: v1.CommandUnion{
+								Exec: &v1.ExecCommand{Component: "runtime"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	d.UpsertCommand(v1.Command{
+		Id: "Build",
+		CommandUnion: v1.CommandUnion{
+			Exec: &v1.ExecCommand{Component: "tools"},
+		},
+	})
+	d.UpsertCommand(v1.Command{
+		Id: "run",
+		CommandUnion: v1.CommandUnion{
+			Exec: &v1.ExecCommand{Component: "runtime"},
+		},
+	})
+
+	wantCommands := []v1.Command{
+		{
+			Id: "build",
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{Component: "tools"},
+			},
+		},
+		{
+			Id: "run",
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{Component: "runtime"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(d.Commands, wantCommands) {
+		t.Errorf("TestDevfile200_UpsertCommand() commands mismatch - wanted: %+v, got: %+v", wantCommands, d.Commands)
+	}
+}
@@ -1,6 +1,9 @@
 package common
 
 import (
+	"fmt"
+	"strings"
+
 	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
 )
 
@@ -25,6 +28,27 @@ func GetGroup(dc v1.Command) *v1.CommandGroup {
 	}
 }
 
+// GetCommandType returns the command type of a given command
+func GetCommandType(command v1.Command) (v1.CommandType, error) {
+	switch {
+	case command.Exec != nil:
+		return v1.ExecCommandType, nil
+	case command.Apply != nil:
+		return v1.ApplyCommandType, nil
+	case command.Composite != nil:
+		return v1.CompositeCommandType, nil
+	case command.VscodeTask != nil:
+		return v1.VscodeTaskCommandType, nil
+	case command.VscodeLaunch != nil:
+		return v1.VscodeLaunchCommandType, nil
+	case command.Custom != nil:
+		return v1.CustomCommandType, nil
+
+	default:
+		return "", fmt.Errorf("unknown command type")
+	}
+}
+
 // GetExecComponent returns the component of the exec command
 func GetExecComponent(dc v1.Command) string {
 	if dc.Exec != nil {
@@ -51,3 +75,74 @@ func GetExecWorkingDir(dc v1.Command) string {
 
 	return ""
 }
+
+// GetCommandsByGroup returns the commands belonging to the group kind
+func GetCommandsByGroup(commands []v1.Command, groupKind v1.CommandGroupKind) []v1.Command {
+	var groupCommands []v1.Command
+	for _, command := range commands {
+		if group := GetGroup(command); group != nil && group.Kind == groupKind {
+			groupCommands = append(groupCommands, command)
+		}
+	}
+	return groupCommands
+}
+
+// GetDefaultCommand returns the default command of the group kind: the single command of the group,
+// or the single command of the group with isDefault set to true if the group has several commands
+func GetDefaultCommand(commands []v1.Command, groupKind v1.CommandGroupKind) (v1.Command, error) {
+	groupCommands := GetCommandsByGroup(commands, groupKind)
+	if len(groupCommands) == 0 {
+		return v1.Command{}, &FieldNotFoundError{Field: "command group", Name: string(groupKind)}
+	}
+	if len(groupCommands) == 1 {
+		return groupCommands[0], nil
+	}
+
+	var defaultCommands []v1.Command
+	var commandIDs []string
+	for _, command := range groupCommands {
+		commandIDs = append(commandIDs, command.Id)
+		if GetGroup(command).IsDefault {
+			defaultCommands = append(defaultCommands, command)
+		}
+	}
+
+	switch len(defaultCommands) {
+	case 0:
+		return v1.Command{}, &NoDefaultCommandError{GroupKind: groupKind, Commands: commandIDs}
+	case 1:
+		return defaultCommands[0], nil
+	default:
+		var defaultCommandIDs []string
+		for _, command := range defaultCommands {
+			defaultCommandIDs = append(defaultCommandIDs, command.Id)
+		}
+		return v1.Command{}, &MultipleDefaultCommandsError{GroupKind: groupKind, Commands: defaultCommandIDs}
+	}
+}
+
+// FilterEvents returns the events with only the command ids of the commands, the ids are compared in lowercase
+func FilterEvents(events v1.Events, commands []v1.Command) v1.Events {
+	commandIDs := make(map[string]bool)
+	for _, command := range commands {
+		commandIDs[strings.ToLower(command.Id)] = true
+	}
+	filter := func(ids []string) []string {
+		var filtered []string
+		for _, id := range ids {
+			if commandIDs[strings.ToLower(id)] {
+				filtered = append(filtered, id)
+			}
+		}
+		return filtered
+	}
+
+	return v1.Events{
+		WorkspaceEvents: v1.WorkspaceEvents{
+			PreStart:  filter(events.PreStart),
+			PostStart: filter(events.PostStart),
+			PreStop:   filter(events.PreStop),
+			PostStop:  filter(events.PostStop),
+		},
+	}
+}
@@ -296,3 +296,134 @@ func TestGetExecWorkingDir(t *testing.T) {
 	}
 
 }
+
+func getGroupCommand(id string, kind v1.CommandGroupKind, isDefault bool) v1.Command {
+	return v1.Command{
+		Id: id,
+		CommandUnion: v1.CommandUnion{
+			Exec: &v1.ExecCommand{
+				LabeledCommand: v1.LabeledCommand{
+					BaseCommand: v1.BaseCommand{
+						Group: &v1.CommandGroup{
+							IsDefault: isDefault,
+							Kind:      kind,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetCommandsByGroup(t *testing.T) {
+
+	commands := []v1.Command{
+		getGroupCommand("build1", v1.BuildCommandGroupKind, false),
+		getGroupCommand("run1", v1.RunCommandGroupKind, true),
+		{
+			Id: "nogroup",
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{},
+			},
+		},
+		getGroupCommand("build2", v1.BuildCommandGroupKind, true),
+	}
+
+	tests := []struct {
+		name      string
+		groupKind v1.CommandGroupKind
+		want      []string
+	}{
+		{
+			name:      "Case 1: Several commands in the group",
+			groupKind: v1.BuildCommandGroupKind,
+			want:      []string{"build1", "build2"},
+		},
+		{
+			name:      "Case 2: No command in the group",
+			groupKind: v1.DebugCommandGroupKind,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var commandIDs []string
+			for _, command := range GetCommandsByGroup(commands, tt.groupKind) {
+				commandIDs = append(commandIDs, command.Id)
+			}
+			if !reflect.DeepEqual(commandIDs, tt.want) {
+				t.Errorf("expected %v, actual %v", tt.want, commandIDs)
+			}
+		})
+	}
+
+}
+
+func TestGetDefaultCommand(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		commands  []v1.Command
+		groupKind v1.CommandGroupKind
+		want      string
+		wantErr   error
+	}{
+		{
+			name: "Case 1: Single command in the group without isDefault",
+			commands: []v1.Command{
+				getGroupCommand("build1", v1.BuildCommandGroupKind, false),
+				getGroupCommand("run1", v1.RunCommandGroupKind, true),
+			},
+			groupKind: v1.BuildCommandGroupKind,
+			want:      "build1",
+		},
+		{
+			name: "Case 2: Default command among several commands",
+			commands: []v1.Command{
+				getGroupCommand("build1", v1.BuildCommandGroupKind, false),
+				getGroupCommand("build2", v1.BuildCommandGroupKind, true),
+			},
+			groupKind: v1.BuildCommandGroupKind,
+			want:      "build2",
+		},
+		{
+			name: "Case 3: No command in the group",
+			commands: []v1.Command{
+				getGroupCommand("build1", v1.BuildCommandGroupKind, true),
+			},
+			groupKind: v1.TestCommandGroupKind,
+			wantErr:   &FieldNotFoundError{Field: "command group", Name: "test"},
+		},
+		{
+			name: "Case 4: No default command among several commands",
+			commands: []v1.Command{
+				getGroupCommand("run1", v1.RunCommandGroupKind, false),
+				getGroupCommand("run2", v1.RunCommandGroupKind, false),
+			},
+			groupKind: v1.RunCommandGroupKind,
+			wantErr:   &NoDefaultCommandError{GroupKind: v1.RunCommandGroupKind, Commands: []string{"run1", "run2"}},
+		},
+		{
+			name: "Case 5: Several default commands",
+			commands: []v1.Command{
+				getGroupCommand("debug1", v1.DebugCommandGroupKind, true),
+				getGroupCommand("debug2", v1.DebugCommandGroupKind, false),
+				getGroupCommand("debug3", v1.DebugCommandGroupKind, true),
+			},
+			groupKind: v1.DebugCommandGroupKind,
+			wantErr:   &MultipleDefaultCommandsError{GroupKind: v1.DebugCommandGroupKind, Commands: []string{"debug1", "debug3"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, err := GetDefaultCommand(tt.commands, tt.groupKind)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("expected error %v, actual %v", tt.wantErr, err)
+				return
+			}
+			if command.Id != tt.want {
+				t.Errorf("expected %v, actual %v", tt.want, command.Id)
+			}
+		})
+	}
+
+}
@@ -1,6 +1,11 @@
 package common
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+)
 
 // FieldAlreadyExistError error returned if tried to add already exisitng field
 type FieldAlreadyExistError struct {
@@ -25,3 +30,55 @@ type FieldNotFoundError struct {
 func (e *FieldNotFoundError) Error() string {
 	return fmt.Sprintf("%s %s is not found in the devfile", e.Field, e.Name)
 }
+
+// NoDefaultCommandError error returned if a group has several commands but none of them is the default
+type NoDefaultCommandError struct {
+	// group kind of the commands
+	GroupKind v1.CommandGroupKind
+	// ids of the commands of the group
+	Commands []string
+}
+
+func (e *NoDefaultCommandError) Error() string {
+	return fmt.Sprintf("none of the %s commands %s is marked as default in the devfile", e.GroupKind, strings.Join(e.Commands, ", "))
+}
+
+// MultipleDefaultCommandsError error returned if a group has several default commands
+type MultipleDefaultCommandsError struct {
+	// group kind of the commands
+	GroupKind v1.CommandGroupKind
+	// ids of the default commands of the group
+	Commands []string
+}
+
+func (e *MultipleDefaultCommandsError) Error() string {
+	return fmt.Sprintf("the %s commands %s are all marked as default in the devfile, only one default command is allowed", e.GroupKind, strings.Join(e.Commands, ", "))
+}
+
+// EndpointPortConflictError error returned if an endpoint uses the port of another endpoint with the same transport protocol
+type EndpointPortConflictError struct {
+	// name of the endpoint
+	Name string
+	// name of the endpoint already using the port
+	ConflictingName string
+	// name of the component of the endpoint already using the port
+	Component string
+	// port of the endpoints
+	Port int
+	// transport protocol of the endpoints, tcp or udp
+	Transport string
+}
+
+func (e *EndpointPortConflictError) Error() string {
+	return fmt.Sprintf("endpoint %s uses the %s port %d already used by the endpoint %s of the component %s", e.Name, e.Transport, e.Port, e.ConflictingName, e.Component)
+}
+
+// DevfileConflictError error returned if the devfile was changed by another writer since it was parsed or last written
+type DevfileConflictError struct {
+	// path of the devfile
+	Path string
+}
+
+func (e *DevfileConflictError) Error() string {
+	return fmt.Sprintf("the devfile %s was changed since it was read, parse it again before writing it", e.Path)
+}
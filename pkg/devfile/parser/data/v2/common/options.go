@@ -1,32 +1,88 @@
 package common
 
 import (
-	"reflect"
+	"strings"
 
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/api/pkg/attributes"
 )
 
 // DevfileOptions provides options for Devfile operations
 type DevfileOptions struct {
 	// Filter is a map that lets you filter devfile object against their attributes. Interface can be string, float, boolean or a map
+	// an object without one of the attributes is filtered out
 	Filter map[string]interface{}
+
+	// Query is a filter expression that lets you filter devfile objects against their attributes, name, type and group kind
+	// it is ANDed with the Filter
+	Query Query
+}
+
+// IsFiltered returns true if the options filter the devfile objects
+func (options DevfileOptions) IsFiltered() bool {
+	return len(options.Filter) > 0 || options.Query != nil
 }
 
 // FilterDevfileObject filters devfile attributes with the given options
 func FilterDevfileObject(attributes attributes.Attributes, options DevfileOptions) (bool, error) {
-	var err error
-	filterIn := true
+	return filterObject(FilterObject{Attributes: attributes}, options)
+}
+
+// FilterDevfileComponent filters the component with the given options
+func FilterDevfileComponent(component v1.Component, options DevfileOptions) (bool, error) {
+	componentType, _ := GetComponentType(component)
+	return filterObject(FilterObject{
+		Name:       component.Name,
+		Type:       string(componentType),
+		Attributes: component.Attributes,
+	}, options)
+}
+
+// FilterDevfileCommand filters the command with the given options, the command id is matched in lowercase
+func FilterDevfileCommand(command v1.Command, options DevfileOptions) (bool, error) {
+	object := FilterObject{
+		Name:       strings.ToLower(command.Id),
+		Attributes: command.Attributes,
+	}
+	commandType, _ := GetCommandType(command)
+	object.Type = string(commandType)
+	if group := GetGroup(command); group != nil {
+		object.GroupKind = string(group.Kind)
+	}
+	return filterObject(object, options)
+}
+
+// FilterDevfileProject filters the project with the given options
+func FilterDevfileProject(project v1.Project, options DevfileOptions) (bool, error) {
+	projectType, _ := GetProjectSourceType(project.ProjectSource)
+	return filterObject(FilterObject{
+		Name:       project.Name,
+		Type:       string(projectType),
+		Attributes: project.Attributes,
+	}, options)
+}
+
+// FilterDevfileStarterProject filters the starter project with the given options
+func FilterDevfileStarterProject(starterProject v1.StarterProject, options DevfileOptions) (bool, error) {
+	projectType, _ := GetProjectSourceType(starterProject.ProjectSource)
+	return filterObject(FilterObject{
+		Name:       starterProject.Name,
+		Type:       string(projectType),
+		Attributes: starterProject.Attributes,
+	}, options)
+}
+
+// filterObject returns true if the object matches every attribute of the Filter and the Query
+func filterObject(object FilterObject, options DevfileOptions) (bool, error) {
 	for key, value := range options.Filter {
-		currentFilterIn := false
-		attrValue := attributes.Get(key, &err)
-		if err != nil {
+		filterIn, err := Equals(AttributeKey(key), value).Match(object)
+		if err != nil || !filterIn {
 			return false, err
-		} else if reflect.DeepEqual(attrValue, value) {
-			currentFilterIn = true
 		}
-
-		filterIn = filterIn && currentFilterIn
 	}
 
-	return filterIn, nil
+	if options.Query == nil {
+		return true, nil
+	}
+	return options.Query.Match(object)
 }
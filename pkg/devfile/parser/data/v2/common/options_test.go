@@ -3,6 +3,7 @@ package common
 import (
 	"testing"
 
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/api/pkg/attributes"
 )
 
@@ -73,3 +74,122 @@ func TestFilterDevfileObject(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterDevfileFields(t *testing.T) {
+
+	container := v1.Component{
+		Name: "runtime",
+		Attributes: attributes.Attributes{}.FromStringMap(map[string]string{
+			"tier": "backend",
+		}),
+		ComponentUnion: v1.ComponentUnion{
+			Container: &v1.ContainerComponent{},
+		},
+	}
+	command := v1.Command{
+		Id: "DevBuild",
+		CommandUnion: v1.CommandUnion{
+			Exec: &v1.ExecCommand{
+				LabeledCommand: v1.LabeledCommand{
+					BaseCommand: v1.BaseCommand{
+						Group: &v1.CommandGroup{
+							Kind: v1.BuildCommandGroupKind,
+						},
+					},
+				},
+			},
+		},
+	}
+	project := v1.Project{
+		Name: "nodejs-starter",
+		ProjectSource: v1.ProjectSource{
+			Git: &v1.GitProjectSource{},
+		},
+	}
+	starterProject := v1.StarterProject{
+		Name: "nodejs-starter",
+		ProjectSource: v1.ProjectSource{
+			Zip: &v1.ZipProjectSource{},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		filter     func(options DevfileOptions) (bool, error)
+		options    DevfileOptions
+		wantFilter bool
+	}{
+		{
+			name: "Case 1: Component type and attribute",
+			filter: func(options DevfileOptions) (bool, error) {
+				return FilterDevfileComponent(container, options)
+			},
+			options: DevfileOptions{
+				Filter: map[string]interface{}{"tier": "backend"},
+				Query:  Equals(TypeKey, v1.ContainerComponentType),
+			},
+			wantFilter: true,
+		},
+		{
+			name: "Case 2: Component with the wrong type",
+			filter: func(options DevfileOptions) (bool, error) {
+				return FilterDevfileComponent(container, options)
+			},
+			options: DevfileOptions{
+				Query: In(TypeKey, v1.VolumeComponentType, v1.KubernetesComponentType),
+			},
+			wantFilter: false,
+		},
+		{
+			name: "Case 3: Command group kind and lowercase id",
+			filter: func(options DevfileOptions) (bool, error) {
+				return FilterDevfileCommand(command, options)
+			},
+			options: DevfileOptions{
+				Query: And(Equals(GroupKindKey, v1.BuildCommandGroupKind), Equals(NameKey, "devbuild"), Equals(TypeKey, v1.ExecCommandType)),
+			},
+			wantFilter: true,
+		},
+		{
+			name: "Case 4: Command without group",
+			filter: func(options DevfileOptions) (bool, error) {
+				return FilterDevfileCommand(v1.Command{Id: "run", CommandUnion: v1.CommandUnion{Exec: &v1.ExecCommand{}}}, options)
+			},
+			options: DevfileOptions{
+				Query: Not(Exists(GroupKindKey)),
+			},
+			wantFilter: true,
+		},
+		{
+			name: "Case 5: Project source type and name regex",
+			filter: func(options DevfileOptions) (bool, error) {
+				return FilterDevfileProject(project, options)
+			},
+			options: DevfileOptions{
+				Query: And(Equals(TypeKey, v1.GitProjectSourceType), MatchesRegexp(NameKey, "^nodejs-")),
+			},
+			wantFilter: true,
+		},
+		{
+			name: "Case 6: Starter project source type",
+			filter: func(options DevfileOptions) (bool, error) {
+				return FilterDevfileStarterProject(starterProject, options)
+			},
+			options: DevfileOptions{
+				Query: Equals(TypeKey, v1.GitProjectSourceType),
+			},
+			wantFilter: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filterIn, err := tt.filter(tt.options)
+			if err != nil {
+				t.Errorf("TestFilterDevfileFields unexpected error - %v", err)
+			} else if filterIn != tt.wantFilter {
+				t.Errorf("TestFilterDevfileFields error - expected %v got %v", tt.wantFilter, filterIn)
+			}
+		})
+	}
+}
@@ -2,10 +2,23 @@ package common
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
 )
 
+const (
+	// DefaultProjectsRoot is the default directory of the projects in the container components
+	DefaultProjectsRoot = "/projects"
+
+	// EnvProjectsRoot is the env holding the directory of the projects in the container components
+	EnvProjectsRoot = "PROJECTS_ROOT"
+
+	// EnvProjectSource is the env holding the directory of the source of the first project in the container components
+	EnvProjectSource = "PROJECT_SOURCE"
+)
+
 // GetDefaultSource get information about primary source
 // returns 3 strings: remote name, remote URL, reference(revision)
 func GetDefaultSource(ps v1.GitLikeProjectSource) (remoteName string, remoteURL string, revision string, err error) {
@@ -41,3 +54,51 @@ func GetDefaultSource(ps v1.GitLikeProjectSource) (remoteName string, remoteURL
 	return remoteName, remoteURL, revision, err
 
 }
+
+// GetProjectSourceType returns the source type of a given project source
+func GetProjectSourceType(projectSrc v1.ProjectSource) (v1.ProjectSourceType, error) {
+	switch {
+	case projectSrc.Git != nil:
+		return v1.GitProjectSourceType, nil
+	case projectSrc.Github != nil:
+		return v1.GitHubProjectSourceType, nil
+	case projectSrc.Zip != nil:
+		return v1.ZipProjectSourceType, nil
+	case projectSrc.Custom != nil:
+		return v1.CustomProjectSourceType, nil
+
+	default:
+		return "", fmt.Errorf("unknown project source type")
+	}
+}
+
+// GetProjectsRoot returns the $PROJECTS_ROOT of a container component with the given source mapping
+func GetProjectsRoot(sourceMapping string) string {
+	if sourceMapping != "" {
+		return sourceMapping
+	}
+	return DefaultProjectsRoot
+}
+
+// GetProjectSource returns the $PROJECT_SOURCE of a container component with the given $PROJECTS_ROOT
+func GetProjectSource(projectsRoot string, projects []v1.Project) (string, error) {
+	// if there are no projects in the devfile, source would be synced to $PROJECTS_ROOT
+	if len(projects) == 0 {
+		return projectsRoot, nil
+	}
+
+	// if there is one or more projects in the devfile, get the first project and check its clonepath
+	project := projects[0]
+	if project.ClonePath == "" {
+		// If clonepath does not exist source would be synced to $PROJECTS_ROOT/projectName
+		return filepath.ToSlash(filepath.Join(projectsRoot, project.Name)), nil
+	}
+	if strings.HasPrefix(project.ClonePath, "/") {
+		return "", fmt.Errorf("the clonePath %s in the devfile project %s must be a relative path", project.ClonePath, project.Name)
+	}
+	if strings.Contains(project.ClonePath, "..") {
+		return "", fmt.Errorf("the clonePath %s in the devfile project %s cannot escape the value defined by $PROJECTS_ROOT. Please avoid using \"..\" in clonePath", project.ClonePath, project.Name)
+	}
+	// If clonepath exist source would be synced to $PROJECTS_ROOT/clonePath
+	return filepath.ToSlash(filepath.Join(projectsRoot, project.ClonePath)), nil
+}
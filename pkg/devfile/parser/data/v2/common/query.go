@@ -0,0 +1,228 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/devfile/api/pkg/attributes"
+	"k8s.io/klog"
+)
+
+// FilterKey is the key of a value matched by a query, either an attribute key or a field of the devfile objects
+type FilterKey struct {
+	field     string
+	attribute string
+}
+
+// AttributeKey returns the filter key of the attribute with the given key
+func AttributeKey(key string) FilterKey {
+	return FilterKey{attribute: key}
+}
+
+var (
+	// NameKey is the filter key of the name of the components, projects and starter projects, and of the id of the commands
+	NameKey = FilterKey{field: "name"}
+
+	// TypeKey is the filter key of the type of the components, commands and projects, such as Container, Exec or Git
+	TypeKey = FilterKey{field: "type"}
+
+	// GroupKindKey is the filter key of the group kind of the commands
+	GroupKindKey = FilterKey{field: "groupKind"}
+)
+
+func (k FilterKey) String() string {
+	if k.field != "" {
+		return k.field
+	}
+	return "attributes." + k.attribute
+}
+
+// FilterObject holds the values of a devfile object matched by the filters, an empty field is absent
+type FilterObject struct {
+	Name       string
+	Type       string
+	GroupKind  string
+	Attributes attributes.Attributes
+}
+
+// get returns the value of the key and whether the key is present in the object
+func (o FilterObject) get(key FilterKey) (interface{}, bool) {
+	var value string
+	switch key.field {
+	case "":
+		if _, ok := o.Attributes[key.attribute]; !ok {
+			return nil, false
+		}
+		var err error
+		attributeValue := o.Attributes.Get(key.attribute, &err)
+		if err != nil {
+			klog.V(4).Infof("ignoring the attribute %s that cannot be decoded: %v", key.attribute, err)
+			return nil, false
+		}
+		return attributeValue, true
+	case NameKey.field:
+		value = o.Name
+	case TypeKey.field:
+		value = o.Type
+	case GroupKindKey.field:
+		value = o.GroupKind
+	}
+	return value, value != ""
+}
+
+// Query is a filter expression matching devfile objects, an absent key doesn't match any value
+type Query interface {
+	Match(object FilterObject) (bool, error)
+}
+
+type equalsQuery struct {
+	key    FilterKey
+	values []interface{}
+}
+
+// Equals returns a query matching the objects with the value for the key. Attribute values are compared
+// after a JSON round trip, so that an int value matches the same number in an attribute
+func Equals(key FilterKey, value interface{}) Query {
+	return equalsQuery{key: key, values: []interface{}{value}}
+}
+
+// In returns a query matching the objects with one of the values for the key
+func In(key FilterKey, values ...interface{}) Query {
+	return equalsQuery{key: key, values: values}
+}
+
+func (q equalsQuery) Match(object FilterObject) (bool, error) {
+	objectValue, ok := object.get(q.key)
+	if !ok {
+		return false, nil
+	}
+	for _, value := range q.values {
+		if q.key.field != "" {
+			if fmt.Sprint(value) == objectValue {
+				return true, nil
+			}
+			continue
+		}
+		jsonValue, err := toJSONValue(value)
+		if err != nil {
+			return false, err
+		}
+		if reflect.DeepEqual(objectValue, jsonValue) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// toJSONValue returns the value as decoded from its JSON encoding
+func toJSONValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter value %v: %v", value, err)
+	}
+	var jsonValue interface{}
+	err = json.Unmarshal(data, &jsonValue)
+	return jsonValue, err
+}
+
+type existsQuery struct {
+	key FilterKey
+}
+
+// Exists returns a query matching the objects with the key
+func Exists(key FilterKey) Query {
+	return existsQuery{key: key}
+}
+
+func (q existsQuery) Match(object FilterObject) (bool, error) {
+	_, ok := object.get(q.key)
+	return ok, nil
+}
+
+type stringQuery struct {
+	key   FilterKey
+	match func(string) bool
+	err   error
+}
+
+// HasPrefix returns a query matching the objects with a string value starting with the prefix for the key
+func HasPrefix(key FilterKey, prefix string) Query {
+	return stringQuery{
+		key: key,
+		match: func(value string) bool {
+			return strings.HasPrefix(value, prefix)
+		},
+	}
+}
+
+// MatchesRegexp returns a query matching the objects with a string value matching the regular expression for the key
+func MatchesRegexp(key FilterKey, pattern string) Query {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return stringQuery{key: key, err: fmt.Errorf("invalid regular expression for %s: %v", key, err)}
+	}
+	return stringQuery{key: key, match: re.MatchString}
+}
+
+func (q stringQuery) Match(object FilterObject) (bool, error) {
+	if q.err != nil {
+		return false, q.err
+	}
+	objectValue, ok := object.get(q.key)
+	if !ok {
+		return false, nil
+	}
+	value, ok := objectValue.(string)
+	return ok && q.match(value), nil
+}
+
+type andQuery []Query
+
+// And returns a query matching the objects matched by all the queries
+func And(queries ...Query) Query {
+	return andQuery(queries)
+}
+
+func (q andQuery) Match(object FilterObject) (bool, error) {
+	for _, query := range q {
+		match, err := query.Match(object)
+		if err != nil || !match {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+type orQuery []Query
+
+// Or returns a query matching the objects matched by one of the queries
+func Or(queries ...Query) Query {
+	return orQuery(queries)
+}
+
+func (q orQuery) Match(object FilterObject) (bool, error) {
+	for _, query := range q {
+		match, err := query.Match(object)
+		if err != nil || match {
+			return match, err
+		}
+	}
+	return false, nil
+}
+
+type notQuery struct {
+	query Query
+}
+
+// Not returns a query matching the objects not matched by the query
+func Not(query Query) Query {
+	return notQuery{query: query}
+}
+
+func (q notQuery) Match(object FilterObject) (bool, error) {
+	match, err := q.query.Match(object)
+	return !match && err == nil, err
+}
@@ -0,0 +1,114 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/devfile/api/pkg/attributes"
+)
+
+func TestQueryMatch(t *testing.T) {
+
+	object := FilterObject{
+		Name: "runtime",
+		Type: "Container",
+		Attributes: attributes.Attributes{}.FromMap(map[string]interface{}{
+			"tier":     "backend",
+			"replicas": 2,
+			"debug":    true,
+			"labels": map[string]interface{}{
+				"app": "nodejs",
+			},
+		}, nil),
+	}
+
+	tests := []struct {
+		name      string
+		query     Query
+		wantMatch bool
+		wantErr   bool
+	}{
+		{
+			name:      "Case 1: Equals on a string attribute",
+			query:     Equals(AttributeKey("tier"), "backend"),
+			wantMatch: true,
+		},
+		{
+			name:      "Case 2: Equals on an int attribute",
+			query:     Equals(AttributeKey("replicas"), 2),
+			wantMatch: true,
+		},
+		{
+			name:      "Case 3: Equals on a map attribute",
+			query:     Equals(AttributeKey("labels"), map[string]string{"app": "nodejs"}),
+			wantMatch: true,
+		},
+		{
+			name:      "Case 4: Equals on a missing attribute",
+			query:     Equals(AttributeKey("missing"), "backend"),
+			wantMatch: false,
+		},
+		{
+			name:      "Case 5: Not equals on a missing attribute",
+			query:     Not(Equals(AttributeKey("missing"), "backend")),
+			wantMatch: true,
+		},
+		{
+			name:      "Case 6: In",
+			query:     In(AttributeKey("tier"), "frontend", "backend"),
+			wantMatch: true,
+		},
+		{
+			name:      "Case 7: Exists",
+			query:     And(Exists(AttributeKey("debug")), Exists(NameKey), Not(Exists(GroupKindKey))),
+			wantMatch: true,
+		},
+		{
+			name:      "Case 8: HasPrefix on a string attribute",
+			query:     HasPrefix(AttributeKey("tier"), "back"),
+			wantMatch: true,
+		},
+		{
+			name:      "Case 9: HasPrefix on a non string attribute",
+			query:     HasPrefix(AttributeKey("replicas"), "2"),
+			wantMatch: false,
+		},
+		{
+			name:      "Case 10: MatchesRegexp on a field",
+			query:     MatchesRegexp(NameKey, "^run.*e$"),
+			wantMatch: true,
+		},
+		{
+			name:    "Case 11: MatchesRegexp with an invalid regexp",
+			query:   MatchesRegexp(NameKey, "(run"),
+			wantErr: true,
+		},
+		{
+			name:      "Case 12: Or",
+			query:     Or(Equals(TypeKey, "Volume"), Equals(AttributeKey("debug"), true)),
+			wantMatch: true,
+		},
+		{
+			name:      "Case 13: And with a failing query",
+			query:     And(Equals(TypeKey, "Container"), Equals(AttributeKey("debug"), false)),
+			wantMatch: false,
+		},
+		{
+			name:      "Case 14: Empty And and Or",
+			query:     And(Not(Or()), And()),
+			wantMatch: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := tt.query.Match(object)
+			if !tt.wantErr && err != nil {
+				t.Errorf("TestQueryMatch unexpected error - %v", err)
+			} else if tt.wantErr && err == nil {
+				t.Errorf("TestQueryMatch wanted error got nil")
+			} else if match != tt.wantMatch {
+				t.Errorf("TestQueryMatch error - expected %v got %v", tt.wantMatch, match)
+			}
+		})
+	}
+}
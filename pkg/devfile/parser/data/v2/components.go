@@ -1,19 +1,22 @@
 package v2
 
 import (
+	"fmt"
+	"strings"
+
 	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 )
 
 // GetComponents returns the slice of Component objects parsed from the Devfile
 func (d *DevfileV2) GetComponents(options common.DevfileOptions) ([]v1.Component, error) {
-	if len(options.Filter) == 0 {
+	if !options.IsFiltered() {
 		return d.Components, nil
 	}
 
 	var components []v1.Component
 	for _, comp := range d.Components {
-		filterIn, err := common.FilterDevfileObject(comp.Attributes, options)
+		filterIn, err := common.FilterDevfileComponent(comp, options)
 		if err != nil {
 			return nil, err
 		}
@@ -60,51 +63,150 @@ func (d *DevfileV2) GetDevfileVolumeComponents(options common.DevfileOptions) ([
 // if a component is already defined, error out
 func (d *DevfileV2) AddComponents(components []v1.Component) error {
 
-	// different map for volume and container component as a volume and a container with same name
+	// different map for volume and the other components as a volume and a container with same name
 	// can exist in devfile
-	containerMap := make(map[string]bool)
+	componentMap := make(map[string]bool)
 	volumeMap := make(map[string]bool)
 
 	for _, component := range d.Components {
 		if component.Volume != nil {
 			volumeMap[component.Name] = true
-		}
-		if component.Container != nil {
-			containerMap[component.Name] = true
+		} else {
+			componentMap[component.Name] = true
 		}
 	}
 
 	for _, component := range components {
-
+		nameMap := componentMap
 		if component.Volume != nil {
-			if _, ok := volumeMap[component.Name]; !ok {
-				d.Components = append(d.Components, component)
-			} else {
-				return &common.FieldAlreadyExistError{Name: component.Name, Field: "component"}
-			}
+			nameMap = volumeMap
 		}
-
-		if component.Container != nil {
-			if _, ok := containerMap[component.Name]; !ok {
-				d.Components = append(d.Components, component)
-			} else {
-				return &common.FieldAlreadyExistError{Name: component.Name, Field: "component"}
-			}
+		if nameMap[component.Name] {
+			return &common.FieldAlreadyExistError{Name: component.Name, Field: "component"}
 		}
+		nameMap[component.Name] = true
+		d.Components = append(d.Components, component)
 	}
 	return nil
 }
 
 // UpdateComponent updates the component with the given name
-func (d *DevfileV2) UpdateComponent(component v1.Component) {
-	index := -1
+// if the component is not found, error out
+func (d *DevfileV2) UpdateComponent(component v1.Component) error {
 	for i := range d.Components {
 		if d.Components[i].Name == component.Name {
-			index = i
-			break
+			d.Components[i] = component
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{
+		Field: "component",
+		Name:  component.Name,
+	}
+}
+
+// UpsertComponent updates the component with the given name, or adds it if it is not defined
+func (d *DevfileV2) UpsertComponent(component v1.Component) {
+	if err := d.UpdateComponent(component); err != nil {
+		d.Components = append(d.Components, component)
+	}
+}
+
+// DeleteComponent removes the component with the given name and type from the devfile. The volume mounts of a deleted volume
+// are removed, a component referenced by exec or apply commands is not deleted, the commands must be deleted first
+func (d *DevfileV2) DeleteComponent(name string, componentType v1.ComponentType) error {
+	index := d.getComponentIndex(name, componentType)
+	if index == -1 {
+		return &common.FieldNotFoundError{
+			Field: "component",
+			Name:  name,
+		}
+	}
+	if componentType != v1.VolumeComponentType {
+		if commandIDs := d.getComponentCommandIDs(name); len(commandIDs) > 0 {
+			return fmt.Errorf("the component %s is referenced by the commands %s", name, strings.Join(commandIDs, ", "))
+		}
+	}
+
+	d.Components = append(d.Components[:index:index], d.Components[index+1:]...)
+	if componentType != v1.VolumeComponentType {
+		return nil
+	}
+	for _, component := range d.Components {
+		if component.Container != nil {
+			var volumeMounts []v1.VolumeMount
+			for _, volumeMount := range component.Container.VolumeMounts {
+				if volumeMount.Name != name {
+					volumeMounts = append(volumeMounts, volumeMount)
+				}
+			}
+			component.Container.VolumeMounts = volumeMounts
 		}
 	}
-	if index != -1 {
-		d.Components[index] = component
+	return nil
+}
+
+// RenameComponent renames the component with the given name and type. The volume mounts of a renamed volume are renamed,
+// the references to any other renamed component in the exec and apply commands are renamed.
+// A volume cannot take the name of another volume, and any other component the name of another component which is not a volume
+func (d *DevfileV2) RenameComponent(oldName, newName string, componentType v1.ComponentType) error {
+	index := d.getComponentIndex(oldName, componentType)
+	if index == -1 {
+		return &common.FieldNotFoundError{
+			Field: "component",
+			Name:  oldName,
+		}
+	}
+	for _, component := range d.Components {
+		if component.Name == newName && (component.Volume != nil) == (componentType == v1.VolumeComponentType) {
+			return &common.FieldAlreadyExistError{
+				Field: "component",
+				Name:  newName,
+			}
+		}
+	}
+
+	d.Components[index].Name = newName
+	if componentType == v1.VolumeComponentType {
+		for i := range d.Components {
+			if d.Components[i].Container != nil {
+				for j := range d.Components[i].Container.VolumeMounts {
+					if d.Components[i].Container.VolumeMounts[j].Name == oldName {
+						d.Components[i].Container.VolumeMounts[j].Name = newName
+					}
+				}
+			}
+		}
+		return nil
+	}
+	for _, command := range d.Commands {
+		if command.Exec != nil && command.Exec.Component == oldName {
+			command.Exec.Component = newName
+		}
+		if command.Apply != nil && command.Apply.Component == oldName {
+			command.Apply.Component = newName
+		}
+	}
+	return nil
+}
+
+// getComponentIndex returns the index of the component with the given name and type, -1 if it is not found
+func (d *DevfileV2) getComponentIndex(name string, componentType v1.ComponentType) int {
+	for i, component := range d.Components {
+		if t, err := common.GetComponentType(component); err == nil && t == componentType && component.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// getComponentCommandIDs returns the ids of the exec and apply commands referencing the component with the given name
+func (d *DevfileV2) getComponentCommandIDs(name string) []string {
+	var commandIDs []string
+	for _, command := range d.Commands {
+		if command.Exec != nil && command.Exec.Component == name || command.Apply != nil && command.Apply.Component == name {
+			commandIDs = append(commandIDs, command.Id)
+		}
 	}
+	return commandIDs
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/devfile/api/pkg/attributes"
 	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 	"github.com/devfile/library/pkg/testingutil"
+	"github.com/kylelemons/godebug/pretty"
 )
 
 func TestDevfile200_AddComponent(t *testing.T) {
@@ -16,6 +17,7 @@ func TestDevfile200_AddComponent(t *testing.T) {
 		name              string
 		currentComponents []v1.Component
 		newComponents     []v1.Component
+		wantComponents    int
 		wantErr           bool
 	}{
 		{
@@ -76,6 +78,52 @@ func TestDevfile200_AddComponent(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "case 3: successfully add the kubernetes and openshift components",
+			currentComponents: []v1.Component{
+				{
+					Name: "component1",
+					ComponentUnion: v1.ComponentUnion{
+						Volume: &v1.VolumeComponent{},
+					},
+				},
+			},
+			newComponents: []v1.Component{
+				{
+					Name: "component1",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{},
+					},
+				},
+				{
+					Name: "component2",
+					ComponentUnion: v1.ComponentUnion{
+						Openshift: &v1.OpenshiftComponent{},
+					},
+				},
+			},
+			wantComponents: 3,
+		},
+		{
+			name: "case 4: error out on a kubernetes component with the name of a container",
+			currentComponents: []v1.Component{
+				{
+					Name: "component1",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{},
+					},
+				},
+			},
+			newComponents: []v1.Component{
+				{
+					Name: "component1",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -96,6 +144,9 @@ func TestDevfile200_AddComponent(t *testing.T) {
 			} else if tt.wantErr && got == nil {
 				t.Errorf("TestDevfile200_AddComponents() expected error but got nil")
 			}
+			if tt.wantComponents != 0 && len(d.Components) != tt.wantComponents {
+				t.Errorf("TestDevfile200_AddComponents() expected %d components, got %d", tt.wantComponents, len(d.Components))
+			}
 
 		})
 	}
@@ -107,6 +158,7 @@ func TestDevfile200_UpdateComponent(t *testing.T) {
 		name              string
 		currentComponents []v1.Component
 		newComponent      v1.Component
+		wantErr           bool
 	}{
 		{
 			name: "case 1: successfully update the component",
@@ -139,6 +191,24 @@ func TestDevfile200_UpdateComponent(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "case 2: fail to update a missing component",
+			currentComponents: []v1.Component{
+				{
+					Name: "component2",
+					ComponentUnion: v1.ComponentUnion{
+						Volume: &v1.VolumeComponent{},
+					},
+				},
+			},
+			newComponent: v1.Component{
+				Name: "component1",
+				ComponentUnion: v1.ComponentUnion{
+					Container: &v1.ContainerComponent{},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -152,7 +222,19 @@ func TestDevfile200_UpdateComponent(t *testing.T) {
 				},
 			}
 
-			d.UpdateComponent(tt.newComponent)
+			err := d.UpdateComponent(tt.newComponent)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestDevfile200_UpdateComponent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, ok := err.(*common.FieldNotFoundError); err != nil && !ok {
+				t.Errorf("TestDevfile200_UpdateComponent() expected a FieldNotFoundError, got %T", err)
+			}
+			if tt.wantErr {
+				if !reflect.DeepEqual(d.Components, tt.currentComponents) {
+					t.Errorf("TestDevfile200_UpdateComponent() components should not be updated - got %v", d.Components)
+				}
+				return
+			}
 
 			components, err := d.GetComponents(common.DevfileOptions{})
 			if err != nil {
@@ -273,6 +355,42 @@ func TestGetDevfileContainerComponents(t *testing.T) {
 			expectedMatchesCount: 0,
 			wantErr:              false,
 		},
+		{
+			name: "Case 6 : Get Container component with a query on attributes and names",
+			component: []v1.Component{
+				{
+					Name: "comp1",
+					Attributes: attributes.Attributes{}.FromStringMap(map[string]string{
+						"firstString": "firstStringValue",
+					}),
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{},
+					},
+				},
+				{
+					Name: "tools",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{},
+					},
+				},
+				{
+					Name: "debugger",
+					Attributes: attributes.Attributes{}.FromStringMap(map[string]string{
+						"firstString": "otherValue",
+					}),
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{},
+					},
+				},
+			},
+			filterOptions: common.DevfileOptions{
+				Query: common.Or(
+					common.HasPrefix(common.AttributeKey("firstString"), "firstString"),
+					common.Not(common.Exists(common.AttributeKey("firstString"))),
+				),
+			},
+			expectedMatchesCount: 2,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -409,3 +527,271 @@ func TestGetDevfileVolumeComponents(t *testing.T) {
 	}
 
 }
+
+func TestDevfile200_DeleteAndRenameComponent(t *testing.T) {
+	dataContainer := v1.Component{
+		Name: "data",
+		ComponentUnion: v1.ComponentUnion{
+			Container: &v1.ContainerComponent{},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		update            func(d *DevfileV2) error
+		wantComponents    []v1.Component
+		commandComponents []string
+		wantErr           bool
+	}{
+		{
+			name: "case 1: delete a volume mounted on a container",
+			update: func(d *DevfileV2) error {
+				return d.DeleteComponent("data", v1.VolumeComponentType)
+			},
+			wantComponents: []v1.Component{
+				{
+					Name: "runtime",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{},
+					},
+				},
+				{
+					Name: "manifests",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{},
+					},
+				},
+			},
+			commandComponents: []string{"runtime", "manifests"},
+		},
+		{
+			name: "case 2: delete a missing component",
+			update: func(d *DevfileV2) error {
+				return d.DeleteComponent("tools", v1.ContainerComponentType)
+			},
+			wantComponents:    getComponentReferencesTestDevfile().Components,
+			commandComponents: []string{"runtime", "manifests"},
+			wantErr:           true,
+		},
+		{
+			name: "case 3: rename a container referenced by a command",
+			update: func(d *DevfileV2) error {
+				return d.RenameComponent("runtime", "tools", v1.ContainerComponentType)
+			},
+			wantComponents: []v1.Component{
+				{
+					Name: "tools",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{
+							Container: v1.Container{
+								VolumeMounts: []v1.VolumeMount{testingutil.GetFakeVolumeMount("data", "/data")},
+							},
+						},
+					},
+				},
+				testingutil.GetFakeVolumeComponent("data", "1Gi"),
+				{
+					Name: "manifests",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{},
+					},
+				},
+			},
+			commandComponents: []string{"tools", "manifests"},
+		},
+		{
+			name: "case 4: rename a volume mounted on a container and a kubernetes component referenced by a command",
+			update: func(d *DevfileV2) error {
+				if err := d.RenameComponent("data", "cache", v1.VolumeComponentType); err != nil {
+					return err
+				}
+				return d.RenameComponent("manifests", "deployment", v1.KubernetesComponentType)
+			},
+			wantComponents: []v1.Component{
+				{
+					Name: "runtime",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{
+							Container: v1.Container{
+								VolumeMounts: []v1.VolumeMount{testingutil.GetFakeVolumeMount("cache", "/data")},
+							},
+						},
+					},
+				},
+				testingutil.GetFakeVolumeComponent("cache", "1Gi"),
+				{
+					Name: "deployment",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{},
+					},
+				},
+			},
+			commandComponents: []string{"runtime", "deployment"},
+		},
+		{
+			name: "case 5: rename a component with the name of another component",
+			update: func(d *DevfileV2) error {
+				return d.RenameComponent("runtime", "manifests", v1.ContainerComponentType)
+			},
+			wantComponents:    getComponentReferencesTestDevfile().Components,
+			commandComponents: []string{"runtime", "manifests"},
+			wantErr:           true,
+		},
+		{
+			name: "case 6: delete a container referenced by a command",
+			update: func(d *DevfileV2) error {
+				return d.DeleteComponent("runtime", v1.ContainerComponentType)
+			},
+			wantComponents:    getComponentReferencesTestDevfile().Components,
+			commandComponents: []string{"runtime", "manifests"},
+			wantErr:           true,
+		},
+		{
+			name: "case 7: delete a volume with the name of a container",
+			update: func(d *DevfileV2) error {
+				d.Components = append(d.Components, dataContainer)
+				return d.DeleteComponent("data", v1.VolumeComponentType)
+			},
+			wantComponents: []v1.Component{
+				{
+					Name: "runtime",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{},
+					},
+				},
+				{
+					Name: "manifests",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{},
+					},
+				},
+				dataContainer,
+			},
+			commandComponents: []string{"runtime", "manifests"},
+		},
+		{
+			name: "case 8: rename a container with the name of a volume",
+			update: func(d *DevfileV2) error {
+				d.Components = append(d.Components, dataContainer)
+				return d.RenameComponent("data", "tools", v1.ContainerComponentType)
+			},
+			wantComponents: append(getComponentReferencesTestDevfile().Components, v1.Component{
+				Name:           "tools",
+				ComponentUnion: dataContainer.ComponentUnion,
+			}),
+			commandComponents: []string{"runtime", "manifests"},
+		},
+		{
+			name: "case 9: rename a volume with the name of a container",
+			update: func(d *DevfileV2) error {
+				return d.RenameComponent("data", "runtime", v1.VolumeComponentType)
+			},
+			wantComponents: []v1.Component{
+				{
+					Name: "runtime",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{
+							Container: v1.Container{
+								VolumeMounts: []v1.VolumeMount{testingutil.GetFakeVolumeMount("runtime", "/data")},
+							},
+						},
+					},
+				},
+				testingutil.GetFakeVolumeComponent("runtime", "1Gi"),
+				{
+					Name: "manifests",
+					ComponentUnion: v1.ComponentUnion{
+						Kubernetes: &v1.KubernetesComponent{},
+					},
+				},
+			},
+			commandComponents: []string{"runtime", "manifests"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := getComponentReferencesTestDevfile()
+
+			err := tt.update(d)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestDevfile200_DeleteAndRenameComponent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(d.Components, tt.wantComponents) {
+				t.Errorf("wanted: %v, got: %v, difference at %v", tt.wantComponents, d.Components, pretty.Compare(tt.wantComponents, d.Components))
+			}
+			commandComponents := []string{d.Commands[0].Exec.Component, d.Commands[1].Apply.Component}
+			if !reflect.DeepEqual(commandComponents, tt.commandComponents) {
+				t.Errorf("TestDevfile200_DeleteAndRenameComponent() command components mismatch - wanted: %v, got: %v", tt.commandComponents, commandComponents)
+			}
+		})
+	}
+}
+
+func getComponentReferencesTestDevfile() *DevfileV2 {
+	return &DevfileV2{
+		v1.Devfile{
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Components: []v1.Component{
+						{
+							Name: "runtime",
+							ComponentUnion: v1.ComponentUnion{
+								Container: &v1.ContainerComponent{
+									Container: v1.Container{
+										VolumeMounts: []v1.VolumeMount{testingutil.GetFakeVolumeMount("data", "/data")},
+									},
+								},
+							},
+						},
+						testingutil.GetFakeVolumeComponent("data", "1Gi"),
+						{
+							Name: "manifests",
+							ComponentUnion: v1.ComponentUnion{
+								Kubernetes: &v1.KubernetesComponent{},
+							},
+						},
+					},
+					Commands: []v1.Command{
+						{
+							Id: "run",
+							CommandUnion: v1.CommandUnion{
+								Exec: &v1.ExecCommand{Component: "runtime"},
+							},
+						},
+						{
+							Id: "deploy",
+							CommandUnion: v1.CommandUnion{
+								Apply: &v1.ApplyCommand{Component: "manifests"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDevfile200_UpsertComponent(t *testing.T) {
+	d := &DevfileV2{
+		v1.Devfile{
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Components: []v1.Component{testingutil.GetFakeVolumeComponent("data", "1Gi")},
+				},
+			},
+		},
+	}
+
+	d.UpsertComponent(testingutil.GetFakeVolumeComponent("data", "5Gi"))
+	d.UpsertComponent(testingutil.GetFakeContainerComponent("runtime"))
+
+	wantComponents := []v1.Component{
+		testingutil.GetFakeVolumeComponent("data", "5Gi"),
+		testingutil.GetFakeContainerComponent("runtime"),
+	}
+	if !reflect.DeepEqual(d.Components, wantComponents) {
+		t.Errorf("wanted: %v, got: %v, difference at %v", wantComponents, d.Components, pretty.Compare(wantComponents, d.Components))
+	}
+}
@@ -6,16 +6,29 @@ import (
 )
 
 // GetEvents returns the Events Object parsed from devfile
-func (d *DevfileV2) GetEvents() v1.Events {
-	if d.Events != nil {
-		return *d.Events
+// if the options filter the devfile objects, only the command ids of the commands matching the options are returned
+func (d *DevfileV2) GetEvents(options common.DevfileOptions) (v1.Events, error) {
+	if d.Events == nil {
+		return v1.Events{}, nil
 	}
-	return v1.Events{}
+	if !options.IsFiltered() {
+		return *d.Events, nil
+	}
+
+	commands, err := d.GetCommands(options)
+	if err != nil {
+		return v1.Events{}, err
+	}
+	return common.FilterEvents(*d.Events, commands), nil
 }
 
 // AddEvents adds the Events Object to the devfile's events
 // if the event is already defined in the devfile, error out
 func (d *DevfileV2) AddEvents(events v1.Events) error {
+	if d.Events == nil {
+		d.Events = &v1.Events{}
+	}
+
 	if len(events.PreStop) > 0 {
 		if len(d.Events.PreStop) > 0 {
 			return &common.FieldAlreadyExistError{Field: "pre stop"}
@@ -50,6 +63,9 @@ func (d *DevfileV2) AddEvents(events v1.Events) error {
 // UpdateEvents updates the devfile's events
 // it only updates the events passed to it
 func (d *DevfileV2) UpdateEvents(postStart, postStop, preStart, preStop []string) {
+	if d.Events == nil {
+		d.Events = &v1.Events{}
+	}
 	if len(postStart) != 0 {
 		d.Events.PostStart = postStart
 	}
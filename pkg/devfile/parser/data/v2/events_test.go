@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 )
 
 func TestDevfile200_AddEvents(t *testing.T) {
@@ -104,7 +105,7 @@ func TestDevfile200_UpdateEvents(t *testing.T) {
 
 			d.UpdateEvents(tt.newEvents.PostStart, tt.newEvents.PostStop, tt.newEvents.PreStart, tt.newEvents.PreStop)
 
-			events := d.GetEvents()
+			events, _ := d.GetEvents(common.DevfileOptions{})
 			if !reflect.DeepEqual(events, tt.newEvents) {
 				t.Errorf("TestDevfile200_UpdateEvents events did not get updated. got - %+v, wanted - %+v", events, tt.newEvents)
 			}
@@ -0,0 +1,303 @@
+package v2
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/attributes"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/devfile/library/pkg/testingutil"
+)
+
+func getTierAttributes(tier string) attributes.Attributes {
+	return attributes.Attributes{}.FromStringMap(map[string]string{
+		"tier": tier,
+	})
+}
+
+func getFilterTestDevfile() *DevfileV2 {
+	runtime := testingutil.GetFakeContainerComponent("runtime")
+	runtime.Attributes = getTierAttributes("backend")
+	runtime.Container.VolumeMounts = []v1.VolumeMount{testingutil.GetFakeVolumeMount("data", "/data")}
+	tools := testingutil.GetFakeContainerComponent("tools")
+	tools.Attributes = getTierAttributes("frontend")
+	data := testingutil.GetFakeVolumeComponent("data", "1Gi")
+	data.Attributes = getTierAttributes("backend")
+
+	return &DevfileV2{
+		v1.Devfile{
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Components: []v1.Component{runtime, tools, data},
+					Commands: []v1.Command{
+						{
+							Id:         "Build",
+							Attributes: getTierAttributes("backend"),
+							CommandUnion: v1.CommandUnion{
+								Exec: &v1.ExecCommand{
+									LabeledCommand: v1.LabeledCommand{
+										BaseCommand: v1.BaseCommand{
+											Group: &v1.CommandGroup{Kind: v1.BuildCommandGroupKind},
+										},
+									},
+									Component: "runtime",
+								},
+							},
+						},
+						{
+							Id:         "run",
+							Attributes: getTierAttributes("frontend"),
+							CommandUnion: v1.CommandUnion{
+								Exec: &v1.ExecCommand{
+									LabeledCommand: v1.LabeledCommand{
+										BaseCommand: v1.BaseCommand{
+											Group: &v1.CommandGroup{Kind: v1.RunCommandGroupKind},
+										},
+									},
+									Component: "tools",
+								},
+							},
+						},
+						{
+							Id: "test",
+							CommandUnion: v1.CommandUnion{
+								Composite: &v1.CompositeCommand{
+									Commands: []string{"Build", "run"},
+								},
+							},
+						},
+					},
+					Events: &v1.Events{
+						WorkspaceEvents: v1.WorkspaceEvents{
+							PreStart:  []string{"Build"},
+							PostStart: []string{"run", "test"},
+						},
+					},
+					Projects: []v1.Project{
+						{
+							Name:       "project1",
+							Attributes: getTierAttributes("backend"),
+							ProjectSource: v1.ProjectSource{
+								Git: &v1.GitProjectSource{},
+							},
+						},
+						{
+							Name: "project2",
+							ProjectSource: v1.ProjectSource{
+								Zip: &v1.ZipProjectSource{},
+							},
+						},
+					},
+					StarterProjects: []v1.StarterProject{
+						{
+							Name:       "starter1",
+							Attributes: getTierAttributes("backend"),
+							ProjectSource: v1.ProjectSource{
+								Git: &v1.GitProjectSource{},
+							},
+						},
+						{
+							Name: "starter2",
+							ProjectSource: v1.ProjectSource{
+								Zip: &v1.ZipProjectSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func componentNames(components []v1.Component, err error) ([]string, error) {
+	var names []string
+	for _, component := range components {
+		names = append(names, component.Name)
+	}
+	return names, err
+}
+
+func commandIDs(commands []v1.Command, err error) ([]string, error) {
+	var ids []string
+	for _, command := range commands {
+		ids = append(ids, command.Id)
+	}
+	return ids, err
+}
+
+// getters returns the results of the getters of the devfile as string slices
+var getters = map[string]func(d *DevfileV2, options common.DevfileOptions) ([]string, error){
+	"GetComponents": func(d *DevfileV2, options common.DevfileOptions) ([]string, error) {
+		return componentNames(d.GetComponents(options))
+	},
+	"GetDevfileContainerComponents": func(d *DevfileV2, options common.DevfileOptions) ([]string, error) {
+		return componentNames(d.GetDevfileContainerComponents(options))
+	},
+	"GetDevfileVolumeComponents": func(d *DevfileV2, options common.DevfileOptions) ([]string, error) {
+		return componentNames(d.GetDevfileVolumeComponents(options))
+	},
+	"GetCommands": func(d *DevfileV2, options common.DevfileOptions) ([]string, error) {
+		return commandIDs(d.GetCommands(options))
+	},
+	"GetCommandsByGroup": func(d *DevfileV2, options common.DevfileOptions) ([]string, error) {
+		return commandIDs(d.GetCommandsByGroup(v1.BuildCommandGroupKind, options))
+	},
+	"GetDefaultCommand": func(d *DevfileV2, options common.DevfileOptions) ([]string, error) {
+		command, err := d.GetDefaultCommand(v1.BuildCommandGroupKind, options)
+		if err != nil {
+			return nil, err
+		}
+		return []string{command.Id}, nil
+	},
+	"GetEvents": func(d *DevfileV2, options common.DevfileOptions) ([]string, error) {
+		events, err := d.GetEvents(options)
+		var ids []string
+		for _, id := range events.PreStart {
+			ids = append(ids, "preStart/"+id)
+		}
+		for _, id := range events.PostStart {
+			ids = append(ids, "postStart/"+id)
+		}
+		return ids, err
+	},
+	"GetVolumeMountPath": func(d *DevfileV2, options common.DevfileOptions) ([]string, error) {
+		path, err := d.GetVolumeMountPath("data", options)
+		if err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	},
+	"GetProjects": func(d *DevfileV2, options common.DevfileOptions) ([]string, error) {
+		projects, err := d.GetProjects(options)
+		var names []string
+		for _, project := range projects {
+			names = append(names, project.Name)
+		}
+		return names, err
+	},
+	"GetStarterProjects": func(d *DevfileV2, options common.DevfileOptions) ([]string, error) {
+		starterProjects, err := d.GetStarterProjects(options)
+		var names []string
+		for _, starterProject := range starterProjects {
+			names = append(names, starterProject.Name)
+		}
+		return names, err
+	},
+}
+
+func TestDevfile200_GettersFilterOptions(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		options common.DevfileOptions
+		want    map[string][]string
+		wantErr []string
+	}{
+		{
+			name:    "Case 1: No filter",
+			options: common.DevfileOptions{},
+			want: map[string][]string{
+				"GetComponents":                 {"runtime", "tools", "data"},
+				"GetDevfileContainerComponents": {"runtime", "tools"},
+				"GetDevfileVolumeComponents":    {"data"},
+				"GetCommands":                   {"build", "run", "test"},
+				"GetCommandsByGroup":            {"build"},
+				"GetDefaultCommand":             {"build"},
+				"GetEvents":                     {"preStart/Build", "postStart/run", "postStart/test"},
+				"GetVolumeMountPath":            {"/data"},
+				"GetProjects":                   {"project1", "project2"},
+				"GetStarterProjects":            {"starter1", "starter2"},
+			},
+		},
+		{
+			name: "Case 2: Attribute filter",
+			options: common.DevfileOptions{
+				Filter: map[string]interface{}{"tier": "backend"},
+			},
+			want: map[string][]string{
+				"GetComponents":                 {"runtime", "data"},
+				"GetDevfileContainerComponents": {"runtime"},
+				"GetDevfileVolumeComponents":    {"data"},
+				"GetCommands":                   {"build"},
+				"GetCommandsByGroup":            {"build"},
+				"GetDefaultCommand":             {"build"},
+				"GetEvents":                     {"preStart/Build"},
+				"GetVolumeMountPath":            {"/data"},
+				"GetProjects":                   {"project1"},
+				"GetStarterProjects":            {"starter1"},
+			},
+		},
+		{
+			name: "Case 3: Query",
+			options: common.DevfileOptions{
+				Query: common.Or(
+					common.Equals(common.AttributeKey("tier"), "frontend"),
+					common.Not(common.Exists(common.AttributeKey("tier"))),
+				),
+			},
+			want: map[string][]string{
+				"GetComponents":                 {"tools"},
+				"GetDevfileContainerComponents": {"tools"},
+				"GetCommands":                   {"run", "test"},
+				"GetEvents":                     {"postStart/run", "postStart/test"},
+				"GetProjects":                   {"project2"},
+				"GetStarterProjects":            {"starter2"},
+			},
+			wantErr: []string{"GetDefaultCommand", "GetVolumeMountPath"},
+		},
+		{
+			name: "Case 4: Attribute filter and query",
+			options: common.DevfileOptions{
+				Filter: map[string]interface{}{"tier": "backend"},
+				Query:  common.In(common.TypeKey, v1.ContainerComponentType, v1.ExecCommandType, v1.GitProjectSourceType),
+			},
+			want: map[string][]string{
+				"GetComponents":                 {"runtime"},
+				"GetDevfileContainerComponents": {"runtime"},
+				"GetCommands":                   {"build"},
+				"GetCommandsByGroup":            {"build"},
+				"GetDefaultCommand":             {"build"},
+				"GetEvents":                     {"preStart/Build"},
+				"GetVolumeMountPath":            {"/data"},
+				"GetProjects":                   {"project1"},
+				"GetStarterProjects":            {"starter1"},
+			},
+		},
+		{
+			name: "Case 5: Filter matching nothing",
+			options: common.DevfileOptions{
+				Filter: map[string]interface{}{"tier": "database"},
+			},
+			want:    map[string][]string{},
+			wantErr: []string{"GetDefaultCommand", "GetVolumeMountPath"},
+		},
+		{
+			name: "Case 6: Invalid query",
+			options: common.DevfileOptions{
+				Query: common.MatchesRegexp(common.NameKey, "(build"),
+			},
+			want: map[string][]string{},
+			wantErr: []string{"GetComponents", "GetDevfileContainerComponents", "GetDevfileVolumeComponents", "GetCommands", "GetCommandsByGroup",
+				"GetDefaultCommand", "GetEvents", "GetVolumeMountPath", "GetProjects", "GetStarterProjects"},
+		},
+	}
+	for _, tt := range tests {
+		for getterName, getter := range getters {
+			t.Run(tt.name+" - "+getterName, func(t *testing.T) {
+				wantErr := false
+				for _, name := range tt.wantErr {
+					wantErr = wantErr || name == getterName
+				}
+
+				got, err := getter(getFilterTestDevfile(), tt.options)
+				if (err != nil) != wantErr {
+					t.Errorf("TestDevfile200_GettersFilterOptions() error = %v, wantErr %v", err, wantErr)
+				}
+				if !reflect.DeepEqual(got, tt.want[getterName]) {
+					t.Errorf("TestDevfile200_GettersFilterOptions() mismatch - wanted: %v, got: %v", tt.want[getterName], got)
+				}
+			})
+		}
+	}
+}
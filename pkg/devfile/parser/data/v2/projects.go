@@ -9,13 +9,13 @@ import (
 
 // GetProjects returns the Project Object parsed from devfile
 func (d *DevfileV2) GetProjects(options common.DevfileOptions) ([]v1.Project, error) {
-	if len(options.Filter) == 0 {
+	if !options.IsFiltered() {
 		return d.Projects, nil
 	}
 
 	var projects []v1.Project
 	for _, proj := range d.Projects {
-		filterIn, err := common.FilterDevfileObject(proj.Attributes, options)
+		filterIn, err := common.FilterDevfileProject(proj, options)
 		if err != nil {
 			return nil, err
 		}
@@ -47,23 +47,40 @@ func (d *DevfileV2) AddProjects(projects []v1.Project) error {
 }
 
 // UpdateProject updates the slice of Devfile projects parsed from the Devfile
-func (d *DevfileV2) UpdateProject(project v1.Project) {
+// if the project is not found, error out
+func (d *DevfileV2) UpdateProject(project v1.Project) error {
+	found := false
 	for i := range d.Projects {
 		if d.Projects[i].Name == strings.ToLower(project.Name) {
 			d.Projects[i] = project
+			found = true
 		}
 	}
+	if !found {
+		return &common.FieldNotFoundError{
+			Field: "project",
+			Name:  project.Name,
+		}
+	}
+	return nil
+}
+
+// UpsertProject updates the project with the given name, or adds it if it is not defined
+func (d *DevfileV2) UpsertProject(project v1.Project) {
+	if err := d.UpdateProject(project); err != nil {
+		d.Projects = append(d.Projects, project)
+	}
 }
 
 //GetStarterProjects returns the DevfileStarterProject parsed from devfile
 func (d *DevfileV2) GetStarterProjects(options common.DevfileOptions) ([]v1.StarterProject, error) {
-	if len(options.Filter) == 0 {
+	if !options.IsFiltered() {
 		return d.StarterProjects, nil
 	}
 
 	var starterProjects []v1.StarterProject
 	for _, starterProj := range d.StarterProjects {
-		filterIn, err := common.FilterDevfileObject(starterProj.Attributes, options)
+		filterIn, err := common.FilterDevfileStarterProject(starterProj, options)
 		if err != nil {
 			return nil, err
 		}
@@ -95,10 +112,105 @@ func (d *DevfileV2) AddStarterProjects(projects []v1.StarterProject) error {
 }
 
 // UpdateStarterProject updates the slice of Devfile starter projects parsed from the Devfile
-func (d *DevfileV2) UpdateStarterProject(project v1.StarterProject) {
+// if the starter project is not found, error out
+func (d *DevfileV2) UpdateStarterProject(project v1.StarterProject) error {
+	found := false
 	for i := range d.StarterProjects {
 		if d.StarterProjects[i].Name == strings.ToLower(project.Name) {
 			d.StarterProjects[i] = project
+			found = true
+		}
+	}
+	if !found {
+		return &common.FieldNotFoundError{
+			Field: "starterProject",
+			Name:  project.Name,
+		}
+	}
+	return nil
+}
+
+// UpsertStarterProject updates the starter project with the given name, or adds it if it is not defined
+func (d *DevfileV2) UpsertStarterProject(project v1.StarterProject) {
+	if err := d.UpdateStarterProject(project); err != nil {
+		d.StarterProjects = append(d.StarterProjects, project)
+	}
+}
+
+// DeleteProject removes the project with the given name from the devfile
+func (d *DevfileV2) DeleteProject(name string) error {
+	for i := range d.Projects {
+		if d.Projects[i].Name == name {
+			d.Projects = append(d.Projects[:i], d.Projects[i+1:]...)
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{
+		Field: "project",
+		Name:  name,
+	}
+}
+
+// RenameProject renames the project with the given name
+func (d *DevfileV2) RenameProject(oldName, newName string) error {
+	index := -1
+	for i := range d.Projects {
+		if d.Projects[i].Name == newName {
+			return &common.FieldAlreadyExistError{
+				Field: "project",
+				Name:  newName,
+			}
+		}
+		if d.Projects[i].Name == oldName {
+			index = i
+		}
+	}
+	if index == -1 {
+		return &common.FieldNotFoundError{
+			Field: "project",
+			Name:  oldName,
+		}
+	}
+
+	d.Projects[index].Name = newName
+	return nil
+}
+
+// DeleteStarterProject removes the starter project with the given name from the devfile
+func (d *DevfileV2) DeleteStarterProject(name string) error {
+	for i := range d.StarterProjects {
+		if d.StarterProjects[i].Name == name {
+			d.StarterProjects = append(d.StarterProjects[:i], d.StarterProjects[i+1:]...)
+			return nil
 		}
 	}
+	return &common.FieldNotFoundError{
+		Field: "starterProject",
+		Name:  name,
+	}
+}
+
+// RenameStarterProject renames the starter project with the given name
+func (d *DevfileV2) RenameStarterProject(oldName, newName string) error {
+	index := -1
+	for i := range d.StarterProjects {
+		if d.StarterProjects[i].Name == newName {
+			return &common.FieldAlreadyExistError{
+				Field: "starterProject",
+				Name:  newName,
+			}
+		}
+		if d.StarterProjects[i].Name == oldName {
+			index = i
+		}
+	}
+	if index == -1 {
+		return &common.FieldNotFoundError{
+			Field: "starterProject",
+			Name:  oldName,
+		}
+	}
+
+	d.StarterProjects[index].Name = newName
+	return nil
 }
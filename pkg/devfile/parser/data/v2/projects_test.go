@@ -88,6 +88,7 @@ func TestDevfile200_UpdateProject(t *testing.T) {
 		args              v1.Project
 		devfilev2         *DevfileV2
 		expectedDevfilev2 *DevfileV2
+		wantErr           bool
 	}{
 		{
 			name: "case:1 It should update project for existing project",
@@ -174,12 +175,16 @@ func TestDevfile200_UpdateProject(t *testing.T) {
 					},
 				},
 			},
+			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.devfilev2.UpdateProject(tt.args)
+			err := tt.devfilev2.UpdateProject(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestDevfile200_UpdateProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
 
 			if !reflect.DeepEqual(tt.devfilev2, tt.expectedDevfilev2) {
 				t.Errorf("wanted: %v, got: %v, difference at %v", tt.expectedDevfilev2, tt.devfilev2, pretty.Compare(tt.expectedDevfilev2, tt.devfilev2))
@@ -271,6 +276,7 @@ func TestDevfile200_UpdateStarterProject(t *testing.T) {
 		args              v1.StarterProject
 		devfilev2         *DevfileV2
 		expectedDevfilev2 *DevfileV2
+		wantErr           bool
 	}{
 		{
 			name: "case:1 It should update project for existing project",
@@ -357,12 +363,16 @@ func TestDevfile200_UpdateStarterProject(t *testing.T) {
 					},
 				},
 			},
+			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.devfilev2.UpdateStarterProject(tt.args)
+			err := tt.devfilev2.UpdateStarterProject(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestDevfile200_UpdateStarterProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
 
 			if !reflect.DeepEqual(tt.devfilev2, tt.expectedDevfilev2) {
 				t.Errorf("wanted: %v, got: %v, difference at %v", tt.expectedDevfilev2, tt.devfilev2, pretty.Compare(tt.expectedDevfilev2, tt.devfilev2))
@@ -370,3 +380,150 @@ func TestDevfile200_UpdateStarterProject(t *testing.T) {
 		})
 	}
 }
+
+func TestDevfile200_DeleteAndRenameProjects(t *testing.T) {
+	tests := []struct {
+		name                string
+		update              func(d *DevfileV2) error
+		wantProjects        []string
+		wantStarterProjects []string
+		wantErr             bool
+	}{
+		{
+			name: "case 1: delete a project",
+			update: func(d *DevfileV2) error {
+				return d.DeleteProject("nodejs")
+			},
+			wantProjects:        []string{"java"},
+			wantStarterProjects: []string{"nodejs-starter", "java-starter"},
+		},
+		{
+			name: "case 2: delete a missing project",
+			update: func(d *DevfileV2) error {
+				return d.DeleteProject("nodejs-starter")
+			},
+			wantProjects:        []string{"nodejs", "java"},
+			wantStarterProjects: []string{"nodejs-starter", "java-starter"},
+			wantErr:             true,
+		},
+		{
+			name: "case 3: rename a project",
+			update: func(d *DevfileV2) error {
+				return d.RenameProject("java", "quarkus")
+			},
+			wantProjects:        []string{"nodejs", "quarkus"},
+			wantStarterProjects: []string{"nodejs-starter", "java-starter"},
+		},
+		{
+			name: "case 4: rename a project with the name of another project",
+			update: func(d *DevfileV2) error {
+				return d.RenameProject("java", "nodejs")
+			},
+			wantProjects:        []string{"nodejs", "java"},
+			wantStarterProjects: []string{"nodejs-starter", "java-starter"},
+			wantErr:             true,
+		},
+		{
+			name: "case 5: delete a starter project",
+			update: func(d *DevfileV2) error {
+				return d.DeleteStarterProject("java-starter")
+			},
+			wantProjects:        []string{"nodejs", "java"},
+			wantStarterProjects: []string{"nodejs-starter"},
+		},
+		{
+			name: "case 6: rename a starter project",
+			update: func(d *DevfileV2) error {
+				return d.RenameStarterProject("nodejs-starter", "express-starter")
+			},
+			wantProjects:        []string{"nodejs", "java"},
+			wantStarterProjects: []string{"express-starter", "java-starter"},
+		},
+		{
+			name: "case 7: rename a missing starter project",
+			update: func(d *DevfileV2) error {
+				return d.RenameStarterProject("nodejs", "express-starter")
+			},
+			wantProjects:        []string{"nodejs", "java"},
+			wantStarterProjects: []string{"nodejs-starter", "java-starter"},
+			wantErr:             true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Projects: []v1.Project{
+								{Name: "nodejs"},
+								{Name: "java"},
+							},
+							StarterProjects: []v1.StarterProject{
+								{Name: "nodejs-starter"},
+								{Name: "java-starter"},
+							},
+						},
+					},
+				},
+			}
+
+			err := tt.update(d)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestDevfile200_DeleteAndRenameProjects() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var projects, starterProjects []string
+			for _, project := range d.Projects {
+				projects = append(projects, project.Name)
+			}
+			for _, starterProject := range d.StarterProjects {
+				starterProjects = append(starterProjects, starterProject.Name)
+			}
+			if !reflect.DeepEqual(projects, tt.wantProjects) {
+				t.Errorf("TestDevfile200_DeleteAndRenameProjects() projects mismatch - wanted: %v, got: %v", tt.wantProjects, projects)
+			}
+			if !reflect.DeepEqual(starterProjects, tt.wantStarterProjects) {
+				t.Errorf("TestDevfile200_DeleteAndRenameProjects() starter projects mismatch - wanted: %v, got: %v", tt.wantStarterProjects, starterProjects)
+			}
+		})
+	}
+}
+
+func TestDevfile200_UpsertProjects(t *testing.T) {
+	d := &DevfileV2{
+		v1.Devfile{
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Projects: []v1.Project{
+						{Name: "nodejs", ClonePath: "/project"},
+					},
+					StarterProjects: []v1.StarterProject{
+						{Name: "nodejs-starter", SubDir: "app"},
+					},
+				},
+			},
+		},
+	}
+
+	d.UpsertProject(v1.Project{Name: "nodejs", ClonePath: "/test"})
+	d.UpsertProject(v1.Project{Name: "java", ClonePath: "/project"})
+	d.UpsertStarterProject(v1.StarterProject{Name: "nodejs-starter", SubDir: "src"})
+	d.UpsertStarterProject(v1.StarterProject{Name: "java-starter"})
+
+	wantProjects := []v1.Project{
+		{Name: "nodejs", ClonePath: "/test"},
+		{Name: "java", ClonePath: "/project"},
+	}
+	wantStarterProjects := []v1.StarterProject{
+		{Name: "nodejs-starter", SubDir: "src"},
+		{Name: "java-starter"},
+	}
+	if !reflect.DeepEqual(d.Projects, wantProjects) {
+		t.Errorf("wanted: %v, got: %v, difference at %v", wantProjects, d.Projects, pretty.Compare(wantProjects, d.Projects))
+	}
+	if !reflect.DeepEqual(d.StarterProjects, wantStarterProjects) {
+		t.Errorf("wanted: %v, got: %v, difference at %v", wantStarterProjects, d.StarterProjects, pretty.Compare(wantStarterProjects, d.StarterProjects))
+	}
+}
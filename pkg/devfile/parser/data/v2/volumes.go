@@ -77,23 +77,29 @@ func (d *DevfileV2) DeleteVolume(name string) error {
 }
 
 // GetVolumeMountPath gets the mount path of the required volume
-func (d *DevfileV2) GetVolumeMountPath(name string) (string, error) {
+// the options filter the container components the volume is mounted on
+func (d *DevfileV2) GetVolumeMountPath(name string, options common.DevfileOptions) (string, error) {
 	volumeFound := false
 	mountFound := false
 	path := ""
 
 	for _, component := range d.Components {
-		if component.Container != nil {
-			for _, volumeMount := range component.Container.VolumeMounts {
-				if volumeMount.Name == name {
-					mountFound = true
-					path = volumeMount.Path
-				}
-			}
-		} else if component.Volume != nil {
+		if component.Volume != nil && component.Name == name {
 			volumeFound = true
 		}
 	}
+	containerComponents, err := d.GetDevfileContainerComponents(options)
+	if err != nil {
+		return "", err
+	}
+	for _, component := range containerComponents {
+		for _, volumeMount := range component.Container.VolumeMounts {
+			if volumeMount.Name == name {
+				mountFound = true
+				path = volumeMount.Path
+			}
+		}
+	}
 	if volumeFound && mountFound {
 		return path, nil
 	} else if !mountFound && volumeFound {
@@ -101,6 +107,6 @@ func (d *DevfileV2) GetVolumeMountPath(name string) (string, error) {
 	}
 	return "", &common.FieldNotFoundError{
 		Field: "volume",
-		Name:  "name",
+		Name:  name,
 	}
 }
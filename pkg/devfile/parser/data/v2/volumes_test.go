@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 	"github.com/devfile/library/pkg/testingutil"
 	"github.com/kylelemons/godebug/pretty"
 )
@@ -487,7 +488,7 @@ func TestDevfile200_GetVolumeMountPath(t *testing.T) {
 					},
 				},
 			}
-			got, err := d.GetVolumeMountPath(tt.args.name)
+			got, err := d.GetVolumeMountPath(tt.args.name, common.DevfileOptions{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetVolumeMountPath() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -27,6 +27,9 @@ type DevfileObj struct {
 
 	// Data has the devfile data
 	Data data.DevfileData
+
+	// inEdit is true for the devfile of a batch edit, the devfile is written once at the end of the edit
+	inEdit bool
 }
 
 // OverrideComponents overrides the components of the parent devfile
@@ -54,12 +57,15 @@ func (d DevfileObj) OverrideComponents(overridePatch []v1.ComponentParentOverrid
 					return errors.Wrap(err, "failed to unmarshal override components")
 				}
 
-				d.Data.UpdateComponent(v1.Component{
-					Name: patchComponent.Name,
+				err = d.Data.UpdateComponent(v1.Component{
+					Name: originalComponent.Name,
 					ComponentUnion: v1.ComponentUnion{
 						Container: &updatedComponent,
 					},
 				})
+				if err != nil {
+					return err
+				}
 			}
 		}
 		if !found {
@@ -102,7 +108,9 @@ func (d DevfileObj) OverrideCommands(overridePatch []v1.CommandParentOverride) (
 					return fmt.Errorf("cannot overide command %q with a different type of command", originalCommand.Id)
 				}
 
-				d.Data.UpdateCommand(devfileCommand)
+				if err := d.Data.UpdateCommand(devfileCommand); err != nil {
+					return err
+				}
 			}
 		}
 		if !found {
@@ -179,7 +187,9 @@ func (d DevfileObj) OverrideProjects(overridePatch []v1.ProjectParentOverride) e
 					return errors.Wrap(err, "failed to unmarshal override projects")
 				}
 
-				d.Data.UpdateProject(updatedProject)
+				if err := d.Data.UpdateProject(updatedProject); err != nil {
+					return err
+				}
 			}
 		}
 		if !found {
@@ -213,7 +223,9 @@ func (d DevfileObj) OverrideStarterProjects(overridePatch []v1.StarterProjectPar
 				if err != nil {
 					return errors.Wrap(err, "failed to unmarshal override starter projects")
 				}
-				d.Data.UpdateStarterProject(updatedProject)
+				if err := d.Data.UpdateStarterProject(updatedProject); err != nil {
+					return err
+				}
 			}
 		}
 		if !found {
@@ -876,6 +876,69 @@ func TestDevfileObj_OverrideComponents(t *testing.T) {
 			wantDevFileObj: DevfileObj{},
 			wantErr:        true,
 		},
+		{
+			name: "case 5: override a container with a patch name in a different case",
+			devFileObj: DevfileObj{
+				Ctx: devfileCtx.NewDevfileCtx(devfileTempPath),
+				Data: &v2.DevfileV2{
+					Devfile: v1.Devfile{
+						DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+							DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+								Components: []v1.Component{
+									{
+										Name: "nodejs",
+										ComponentUnion: v1.ComponentUnion{
+											Container: &v1.ContainerComponent{
+												Container: v1.Container{
+													Image: containerImage0,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				overridePatch: []v1.ComponentParentOverride{
+					{
+						Name: "NodeJS",
+						ComponentUnionParentOverride: v1.ComponentUnionParentOverride{
+							Container: &v1.ContainerComponentParentOverride{
+								ContainerParentOverride: v1.ContainerParentOverride{
+									Image: overrideContainerImage,
+								},
+							},
+						},
+					},
+				},
+			},
+			wantDevFileObj: DevfileObj{
+				Ctx: devfileCtx.NewDevfileCtx(devfileTempPath),
+				Data: &v2.DevfileV2{
+					Devfile: v1.Devfile{
+						DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+							DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+								Components: []v1.Component{
+									{
+										Name: "nodejs",
+										ComponentUnion: v1.ComponentUnion{
+											Container: &v1.ContainerComponent{
+												Container: v1.Container{
+													Image: overrideContainerImage,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
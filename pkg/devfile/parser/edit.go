@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	devfileCtx "github.com/devfile/library/pkg/devfile/parser/context"
+	"github.com/devfile/library/pkg/devfile/parser/data"
+	"github.com/devfile/library/pkg/devfile/validate"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+)
+
+// Edit applies a batch of edits to the devfile. The edit function is called with a copy of the devfile, the configurables
+// and the data methods called on the copy don't write the devfile. If the edit function succeeds, the edited devfile is
+// validated against the JSON schema and the semantic rules and written once in the format of the devfile, atomically and under the devfile lock; the devfile data is then replaced
+// with the edited data. If the edit function or the validation fail, neither the devfile data nor the file are changed.
+// Edits cannot be nested, Edit errors out if called on the devfile of an edit
+func (d *DevfileObj) Edit(edit func(tx DevfileObj) error) error {
+	if d.inEdit {
+		return fmt.Errorf("the devfile is already being edited, nested edits are not supported")
+	}
+	txData, err := copyDevfileData(d.Data)
+	if err != nil {
+		return err
+	}
+	tx := DevfileObj{
+		Ctx:    d.Ctx,
+		Data:   txData,
+		inEdit: true,
+	}
+	if err := edit(tx); err != nil {
+		return err
+	}
+
+	// the devfile keeps its format
+	content, err := marshalDevfile(tx.Data, d.isJSONDevfile())
+	if err != nil {
+		return err
+	}
+	if err := validateDevfileContent(content); err != nil {
+		return err
+	}
+	if err := validate.ValidateDevfileData(tx.Data); err != nil {
+		return err
+	}
+
+	if err := d.writeDevfile(content); err != nil {
+		return errors.Wrapf(err, "failed to write devfile file")
+	}
+	d.Data = tx.Data
+	klog.V(2).Infof("devfile edited at: '%s'", d.Ctx.GetAbsPath())
+	return nil
+}
+
+// copyDevfileData returns a deep copy of the devfile data
+func copyDevfileData(devfileData data.DevfileData) (data.DevfileData, error) {
+	dataType := reflect.TypeOf(devfileData)
+	if dataType == nil || dataType.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("unable to copy the devfile data of type %T", devfileData)
+	}
+
+	jsonData, err := json.Marshal(devfileData)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal devfile object into json")
+	}
+	dataCopy := reflect.New(dataType.Elem()).Interface().(data.DevfileData)
+	if err := json.Unmarshal(jsonData, dataCopy); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode devfile content")
+	}
+	return dataCopy, nil
+}
+
+// validateDevfileContent validates the devfile content against the JSON schema of its schema version
+func validateDevfileContent(content []byte) error {
+	ctx := devfileCtx.NewDevfileCtx("")
+	if err := ctx.SetDevfileContentFromBytes(content); err != nil {
+		return err
+	}
+	if err := ctx.PopulateFromRaw(); err != nil {
+		return err
+	}
+	return ctx.Validate()
+}
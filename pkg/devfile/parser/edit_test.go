@@ -0,0 +1,237 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	devfilepkg "github.com/devfile/api/pkg/devfile"
+	devfileCtx "github.com/devfile/library/pkg/devfile/parser/context"
+	v2 "github.com/devfile/library/pkg/devfile/parser/data/v2"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/devfile/library/pkg/testingutil/filesystem"
+	"sigs.k8s.io/yaml"
+)
+
+// countingFs counts the files renamed into place
+type countingFs struct {
+	filesystem.Filesystem
+	renames int
+}
+
+func (fs *countingFs) Rename(oldpath, newpath string) error {
+	fs.renames++
+	return fs.Filesystem.Rename(oldpath, newpath)
+}
+
+func getEditTestDevfileObj(fs filesystem.Filesystem) DevfileObj {
+	return DevfileObj{
+		Ctx: devfileCtx.FakeContext(fs, OutputDevfileYamlPath),
+		Data: &v2.DevfileV2{
+			Devfile: v1.Devfile{
+				DevfileHeader: devfilepkg.DevfileHeader{
+					SchemaVersion: "2.0.0",
+					Metadata: devfilepkg.DevfileMetadata{
+						Name: "nodejs",
+					},
+				},
+				DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+					DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+						Components: []v1.Component{
+							{
+								Name: "runtime",
+								ComponentUnion: v1.ComponentUnion{
+									Container: &v1.ContainerComponent{
+										Container: v1.Container{
+											Image: "quay.io/nodejs-12",
+										},
+									},
+								},
+							},
+						},
+						Commands: []v1.Command{
+							{
+								Id: "run",
+								CommandUnion: v1.CommandUnion{
+									Exec: &v1.ExecCommand{
+										CommandLine: "npm start",
+										Component:   "runtime",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDevfileObj_Edit(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		edit        func(tx DevfileObj) error
+		wantErr     string
+		wantWritten bool
+		wantName    string
+		wantEnv     []v1.EnvVar
+	}{
+		{
+			name: "case 1: batch several edits",
+			edit: func(tx DevfileObj) error {
+				if err := tx.SetMetadataName("express"); err != nil {
+					return err
+				}
+				if err := tx.AddEnvVars([]v1.EnvVar{{Name: "PORT", Value: "3000"}}); err != nil {
+					return err
+				}
+				return tx.SetPorts("3000")
+			},
+			wantWritten: true,
+			wantName:    "express",
+			wantEnv:     []v1.EnvVar{{Name: "PORT", Value: "3000"}},
+		},
+		{
+			name: "case 2: an edit fails",
+			edit: func(tx DevfileObj) error {
+				if err := tx.SetMetadataName("express"); err != nil {
+					return err
+				}
+				if err := tx.AddEnvVars([]v1.EnvVar{{Name: "PORT", Value: "3000"}}); err != nil {
+					return err
+				}
+				return tx.RemoveEnvVars([]string{"DEBUG"})
+			},
+			wantErr:  "unable to find environment variable DEBUG",
+			wantName: "nodejs",
+		},
+		{
+			name: "case 3: the edited devfile is semantically invalid",
+			edit: func(tx DevfileObj) error {
+				return tx.Data.AddCommands(v1.Command{
+					Id: "debug",
+					CommandUnion: v1.CommandUnion{
+						Exec: &v1.ExecCommand{
+							CommandLine: "npm run debug",
+							Component:   "tools",
+						},
+					},
+				})
+			},
+			wantErr:  "component tools of the command debug is not found",
+			wantName: "nodejs",
+		},
+		{
+			name: "case 4: the edited devfile is invalid against the schema",
+			edit: func(tx DevfileObj) error {
+				tx.Data.SetMetadata("nodejs", "latest")
+				return nil
+			},
+			wantErr:  "invalid devfile schema",
+			wantName: "nodejs",
+		},
+		{
+			name: "case 5: a nested edit",
+			edit: func(tx DevfileObj) error {
+				if err := tx.SetMetadataName("express"); err != nil {
+					return err
+				}
+				return tx.Edit(func(nested DevfileObj) error {
+					return nested.SetMetadataName("nested")
+				})
+			},
+			wantErr:  "nested edits are not supported",
+			wantName: "nodejs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := &countingFs{Filesystem: filesystem.NewFakeFs()}
+			d := getEditTestDevfileObj(fs)
+
+			err := d.Edit(tt.edit)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("TestDevfileObj_Edit() unexpected error %v", err)
+			} else if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("TestDevfileObj_Edit() error = %v, wanted an error containing %q", err, tt.wantErr)
+			}
+
+			if d.Data.GetMetadata().Name != tt.wantName {
+				t.Errorf("TestDevfileObj_Edit() metadata name mismatch - wanted: %s, got: %s", tt.wantName, d.Data.GetMetadata().Name)
+			}
+			components, _ := d.Data.GetDevfileContainerComponents(common.DevfileOptions{})
+			if len(components) != 1 || !reflect.DeepEqual(components[0].Container.Env, tt.wantEnv) {
+				t.Errorf("TestDevfileObj_Edit() components mismatch - got: %+v", components)
+			}
+
+			wantRenames := 0
+			if tt.wantWritten {
+				wantRenames = 1
+			}
+			if fs.renames != wantRenames {
+				t.Errorf("TestDevfileObj_Edit() expected %d write, got %d", wantRenames, fs.renames)
+			}
+			files, err := fs.ReadDir(".")
+			if err != nil {
+				t.Errorf("TestDevfileObj_Edit() unexpected error %v", err)
+			}
+			if len(files) != wantRenames {
+				t.Errorf("TestDevfileObj_Edit() expected %d file, got %d", wantRenames, len(files))
+			}
+			if !tt.wantWritten {
+				return
+			}
+
+			content, err := fs.ReadFile(OutputDevfileYamlPath)
+			if err != nil {
+				t.Errorf("TestDevfileObj_Edit() unexpected error %v", err)
+				return
+			}
+			var written v2.DevfileV2
+			if err := yaml.Unmarshal(content, &written); err != nil {
+				t.Errorf("TestDevfileObj_Edit() unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(&written, d.Data) {
+				t.Errorf("TestDevfileObj_Edit() written devfile mismatch - wanted: %v, got: %v", d.Data, fmt.Sprintf("%s", content))
+			}
+		})
+	}
+}
+
+func TestDevfileObj_EditJSON(t *testing.T) {
+
+	fs := filesystem.NewFakeFs()
+	d := getEditTestDevfileObj(fs)
+	d.Ctx = devfileCtx.FakeContext(fs, OutputDevfileJsonPath)
+	content, err := json.MarshalIndent(d.Data, "", "  ")
+	if err != nil {
+		t.Fatalf("TestDevfileObj_EditJSON() unexpected error %v", err)
+	}
+	if err := fs.WriteFile(OutputDevfileJsonPath, content, 0644); err != nil {
+		t.Fatalf("TestDevfileObj_EditJSON() unexpected error %v", err)
+	}
+
+	err = d.Edit(func(tx DevfileObj) error {
+		return tx.SetMetadataName("express")
+	})
+	if err != nil {
+		t.Fatalf("TestDevfileObj_EditJSON() unexpected error %v", err)
+	}
+
+	content, err = fs.ReadFile(OutputDevfileJsonPath)
+	if err != nil {
+		t.Fatalf("TestDevfileObj_EditJSON() unexpected error %v", err)
+	}
+	var written v2.DevfileV2
+	if err := json.Unmarshal(content, &written); err != nil {
+		t.Fatalf("TestDevfileObj_EditJSON() the edited devfile is not in the JSON format: %v", err)
+	}
+	if written.Metadata.Name != "express" || !reflect.DeepEqual(&written, d.Data) {
+		t.Errorf("TestDevfileObj_EditJSON() written devfile mismatch - wanted: %v, got: %s", d.Data, content)
+	}
+}
@@ -0,0 +1,172 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+)
+
+// AddEndpoint adds the endpoint to the container component with the given name and writes the devfile.
+// The endpoint name must be unique in the devfile and its port must not be used by another endpoint with the same transport protocol
+func (d DevfileObj) AddEndpoint(componentName string, endpoint v1.Endpoint) error {
+	endpoint, err := normalizeEndpoint(endpoint)
+	if err != nil {
+		return err
+	}
+	if err := d.checkEndpoint(endpoint, false); err != nil {
+		return err
+	}
+	return d.updateContainerComponents(componentName, common.DevfileOptions{}, func(container *v1.ContainerComponent) error {
+		container.Endpoints = append(container.Endpoints, endpoint)
+		return nil
+	})
+}
+
+// UpdateEndpoint replaces the container endpoint with the same name by the endpoint and writes the devfile.
+// The port of the endpoint must not be used by another endpoint with the same transport protocol
+func (d DevfileObj) UpdateEndpoint(endpoint v1.Endpoint) error {
+	endpoint, err := normalizeEndpoint(endpoint)
+	if err != nil {
+		return err
+	}
+	componentName, err := d.getEndpointComponent(endpoint.Name)
+	if err != nil {
+		return err
+	}
+	if err := d.checkEndpoint(endpoint, true); err != nil {
+		return err
+	}
+	return d.updateContainerComponents(componentName, common.DevfileOptions{}, func(container *v1.ContainerComponent) error {
+		for i := range container.Endpoints {
+			if container.Endpoints[i].Name == endpoint.Name {
+				container.Endpoints[i] = endpoint
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveEndpoint removes the container endpoint with the given name and writes the devfile
+func (d DevfileObj) RemoveEndpoint(name string) error {
+	componentName, err := d.getEndpointComponent(name)
+	if err != nil {
+		return err
+	}
+	return d.updateContainerComponents(componentName, common.DevfileOptions{}, func(container *v1.ContainerComponent) error {
+		var endpoints []v1.Endpoint
+		for _, endpoint := range container.Endpoints {
+			if endpoint.Name != name {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+		container.Endpoints = endpoints
+		return nil
+	})
+}
+
+// getEndpointComponent returns the name of the container component with the endpoint with the given name
+func (d DevfileObj) getEndpointComponent(name string) (string, error) {
+	components, err := d.Data.GetDevfileContainerComponents(common.DevfileOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, component := range components {
+		for _, endpoint := range component.Container.Endpoints {
+			if endpoint.Name == name {
+				return component.Name, nil
+			}
+		}
+	}
+	return "", &common.FieldNotFoundError{
+		Field: "endpoint",
+		Name:  name,
+	}
+}
+
+// checkEndpoint checks that the endpoint name is unique and that the endpoint port is not used by another endpoint
+// of a component with the same transport protocol. If replace is true, the endpoint with the same name is ignored
+func (d DevfileObj) checkEndpoint(endpoint v1.Endpoint, replace bool) error {
+	components, err := d.Data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+	transport := getEndpointTransport(endpoint.Protocol)
+	for _, component := range components {
+		for _, other := range getComponentEndpoints(component) {
+			if other.Name == endpoint.Name {
+				if replace {
+					continue
+				}
+				return &common.FieldAlreadyExistError{
+					Field: "endpoint",
+					Name:  endpoint.Name,
+				}
+			}
+			if other.TargetPort == endpoint.TargetPort && getEndpointTransport(other.Protocol) == transport {
+				return &common.EndpointPortConflictError{
+					Name:            endpoint.Name,
+					ConflictingName: other.Name,
+					Component:       component.Name,
+					Port:            endpoint.TargetPort,
+					Transport:       transport,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeEndpoint checks the endpoint fields and returns the endpoint with a lowercase protocol and exposure.
+// The https and wss protocols are not in the devfile protocol enum, they are set as the secure http and ws protocols
+func normalizeEndpoint(endpoint v1.Endpoint) (v1.Endpoint, error) {
+	if endpoint.Name == "" {
+		return endpoint, fmt.Errorf("endpoint name is required")
+	}
+	if endpoint.TargetPort < 1 || endpoint.TargetPort > 65535 {
+		return endpoint, fmt.Errorf("invalid target port %d of the endpoint %s", endpoint.TargetPort, endpoint.Name)
+	}
+
+	endpoint.Protocol = v1.EndpointProtocol(strings.ToLower(string(endpoint.Protocol)))
+	switch endpoint.Protocol {
+	case "", v1.HTTPEndpointProtocol, v1.WSEndpointProtocol, v1.TCPEndpointProtocol, v1.UDPEndpointProtocol:
+	case v1.HTTPSEndpointProtocol:
+		endpoint.Protocol = v1.HTTPEndpointProtocol
+		endpoint.Secure = true
+	case v1.WSSEndpointProtocol:
+		endpoint.Protocol = v1.WSEndpointProtocol
+		endpoint.Secure = true
+	default:
+		return endpoint, fmt.Errorf("invalid protocol %s of the endpoint %s, the protocol must be one of http, https, ws, wss, tcp, udp", endpoint.Protocol, endpoint.Name)
+	}
+
+	endpoint.Exposure = v1.EndpointExposure(strings.ToLower(string(endpoint.Exposure)))
+	switch endpoint.Exposure {
+	case "", v1.PublicEndpointExposure, v1.InternalEndpointExposure, v1.NoneEndpointExposure:
+	default:
+		return endpoint, fmt.Errorf("invalid exposure %s of the endpoint %s, the exposure must be one of public, internal, none", endpoint.Exposure, endpoint.Name)
+	}
+	return endpoint, nil
+}
+
+// getEndpointTransport returns the transport protocol of the endpoint protocol, the endpoint protocol default value is http
+func getEndpointTransport(protocol v1.EndpointProtocol) string {
+	if strings.ToLower(string(protocol)) == string(v1.UDPEndpointProtocol) {
+		return "udp"
+	}
+	return "tcp"
+}
+
+// getComponentEndpoints returns the endpoints of the component
+func getComponentEndpoints(component v1.Component) []v1.Endpoint {
+	switch {
+	case component.Container != nil:
+		return component.Container.Endpoints
+	case component.Kubernetes != nil:
+		return component.Kubernetes.Endpoints
+	case component.Openshift != nil:
+		return component.Openshift.Endpoints
+	}
+	return nil
+}
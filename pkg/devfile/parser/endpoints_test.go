@@ -0,0 +1,184 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/devfile/library/pkg/testingutil/filesystem"
+)
+
+func TestEndpoints(t *testing.T) {
+
+	fs := filesystem.NewFakeFs()
+
+	tests := []struct {
+		name          string
+		update        func(d DevfileObj) error
+		wantEndpoints map[string][]v1.Endpoint
+		wantErr       interface{}
+	}{
+		{
+			name: "case 1: add an endpoint with all the fields",
+			update: func(d DevfileObj) error {
+				return d.AddEndpoint("loadbalancer", v1.Endpoint{
+					Name:       "web",
+					TargetPort: 8080,
+					Exposure:   "Public",
+					Protocol:   "HTTP",
+					Secure:     true,
+					Path:       "/api",
+				})
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime":      {{Name: "port-3030", TargetPort: 3000}},
+				"loadbalancer": {{Name: "web", TargetPort: 8080, Exposure: "public", Protocol: "http", Secure: true, Path: "/api"}},
+			},
+		},
+		{
+			name: "case 2: add endpoints with the https and wss protocols",
+			update: func(d DevfileObj) error {
+				if err := d.AddEndpoint("runtime", v1.Endpoint{Name: "web", TargetPort: 8443, Protocol: "https"}); err != nil {
+					return err
+				}
+				return d.AddEndpoint("runtime", v1.Endpoint{Name: "socket", TargetPort: 8444, Protocol: "wss"})
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime": {
+					{Name: "port-3030", TargetPort: 3000},
+					{Name: "web", TargetPort: 8443, Protocol: "http", Secure: true},
+					{Name: "socket", TargetPort: 8444, Protocol: "ws", Secure: true},
+				},
+			},
+		},
+		{
+			name: "case 3: add an endpoint on a port used with another transport protocol",
+			update: func(d DevfileObj) error {
+				return d.AddEndpoint("loadbalancer", v1.Endpoint{Name: "dns", TargetPort: 3000, Protocol: "udp"})
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime":      {{Name: "port-3030", TargetPort: 3000}},
+				"loadbalancer": {{Name: "dns", TargetPort: 3000, Protocol: "udp"}},
+			},
+		},
+		{
+			name: "case 4: add an endpoint with an existing name",
+			update: func(d DevfileObj) error {
+				return d.AddEndpoint("loadbalancer", v1.Endpoint{Name: "port-3030", TargetPort: 3030})
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime": {{Name: "port-3030", TargetPort: 3000}},
+			},
+			wantErr: &common.FieldAlreadyExistError{},
+		},
+		{
+			name: "case 5: add an endpoint on a port used by another component",
+			update: func(d DevfileObj) error {
+				return d.AddEndpoint("loadbalancer", v1.Endpoint{Name: "tcp", TargetPort: 3000, Protocol: "tcp"})
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime": {{Name: "port-3030", TargetPort: 3000}},
+			},
+			wantErr: &common.EndpointPortConflictError{},
+		},
+		{
+			name: "case 6: add an endpoint with an invalid protocol",
+			update: func(d DevfileObj) error {
+				return d.AddEndpoint("loadbalancer", v1.Endpoint{Name: "grpc", TargetPort: 9090, Protocol: "grpc"})
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime": {{Name: "port-3030", TargetPort: 3000}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 7: add an endpoint to a missing component",
+			update: func(d DevfileObj) error {
+				return d.AddEndpoint("database", v1.Endpoint{Name: "db", TargetPort: 5432})
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime": {{Name: "port-3030", TargetPort: 3000}},
+			},
+			wantErr: &common.FieldNotFoundError{},
+		},
+		{
+			name: "case 8: update an endpoint",
+			update: func(d DevfileObj) error {
+				return d.UpdateEndpoint(v1.Endpoint{Name: "port-3030", TargetPort: 3000, Exposure: "internal", Path: "/health"})
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime": {{Name: "port-3030", TargetPort: 3000, Exposure: "internal", Path: "/health"}},
+			},
+		},
+		{
+			name: "case 9: update an endpoint on a port used by another endpoint",
+			update: func(d DevfileObj) error {
+				if err := d.AddEndpoint("loadbalancer", v1.Endpoint{Name: "web", TargetPort: 8080}); err != nil {
+					return err
+				}
+				return d.UpdateEndpoint(v1.Endpoint{Name: "web", TargetPort: 3000})
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime":      {{Name: "port-3030", TargetPort: 3000}},
+				"loadbalancer": {{Name: "web", TargetPort: 8080}},
+			},
+			wantErr: &common.EndpointPortConflictError{},
+		},
+		{
+			name: "case 10: update a missing endpoint",
+			update: func(d DevfileObj) error {
+				return d.UpdateEndpoint(v1.Endpoint{Name: "web", TargetPort: 8080})
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime": {{Name: "port-3030", TargetPort: 3000}},
+			},
+			wantErr: &common.FieldNotFoundError{},
+		},
+		{
+			name: "case 11: remove an endpoint",
+			update: func(d DevfileObj) error {
+				if err := d.AddEndpoint("runtime", v1.Endpoint{Name: "debug", TargetPort: 5858}); err != nil {
+					return err
+				}
+				return d.RemoveEndpoint("port-3030")
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime": {{Name: "debug", TargetPort: 5858}},
+			},
+		},
+		{
+			name: "case 12: remove a missing endpoint",
+			update: func(d DevfileObj) error {
+				return d.RemoveEndpoint("web")
+			},
+			wantEndpoints: map[string][]v1.Endpoint{
+				"runtime": {{Name: "port-3030", TargetPort: 3000}},
+			},
+			wantErr: &common.FieldNotFoundError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testDevfileObj(fs)
+
+			err := tt.update(d)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestEndpoints() error = %v, wantErr %v", err, tt.wantErr)
+			} else if _, ok := tt.wantErr.(error); ok && reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("TestEndpoints() expected an error of type %T, got %T", tt.wantErr, err)
+			}
+
+			components, err := d.Data.GetDevfileContainerComponents(common.DevfileOptions{})
+			if err != nil {
+				t.Errorf("TestEndpoints() unexpected error %v", err)
+			}
+			for _, component := range components {
+				if !reflect.DeepEqual(component.Container.Endpoints, tt.wantEndpoints[component.Name]) {
+					t.Errorf("TestEndpoints() endpoints mismatch for %s - wanted: %v, got: %v", component.Name, tt.wantEndpoints[component.Name], component.Container.Endpoints)
+				}
+			}
+		})
+	}
+}
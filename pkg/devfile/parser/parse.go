@@ -132,7 +132,11 @@ func parseParent(d DevfileObj) error {
 	if err != nil {
 		return errors.Wrapf(err, "error while getting commands from the parent devfiles")
 	}
-	err = d.Data.AddCommands(parentCommands...)
+	localCommands, err := d.Data.GetCommands(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+	err = d.Data.AddCommands(getParentCommands(parentCommands, localCommands)...)
 	if err != nil {
 		return errors.Wrapf(err, "error while adding commands from the parent devfiles")
 	}
@@ -164,9 +168,35 @@ func parseParent(d DevfileObj) error {
 		return errors.Wrapf(err, "error while adding starter projects from the parent devfiles")
 	}
 
-	err = d.Data.AddEvents(parentData.Data.GetEvents())
+	parentEvents, err := parentData.Data.GetEvents(common.DevfileOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "error while getting events from the parent devfiles")
+	}
+	err = d.Data.AddEvents(parentEvents)
 	if err != nil {
 		return errors.Wrapf(err, "error while adding events from the parent devfiles")
 	}
 	return nil
 }
+
+// getParentCommands returns the parent commands to add to the local devfile, the local default commands take precedence
+// over the parent default commands: a parent command is not marked as default if a local command of its group is
+func getParentCommands(parentCommands, localCommands []v1.Command) []v1.Command {
+	localDefaults := make(map[v1.CommandGroupKind]bool)
+	for _, command := range localCommands {
+		if group := common.GetGroup(command); group != nil && group.IsDefault {
+			localDefaults[group.Kind] = true
+		}
+	}
+
+	var commands []v1.Command
+	for _, command := range parentCommands {
+		if group := common.GetGroup(command); group != nil && group.IsDefault && localDefaults[group.Kind] {
+			klog.V(4).Infof("the parent command %s is not the default %s command since the devfile defines one", command.Id, group.Kind)
+			command = *command.DeepCopy()
+			common.GetGroup(command).IsDefault = false
+		}
+		commands = append(commands, command)
+	}
+	return commands
+}
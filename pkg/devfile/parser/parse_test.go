@@ -727,3 +727,44 @@ func Test_parseParent(t *testing.T) {
 		})
 	}
 }
+
+func Test_getParentCommands(t *testing.T) {
+	getCommand := func(id string, kind v1.CommandGroupKind, isDefault bool) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					LabeledCommand: v1.LabeledCommand{
+						BaseCommand: v1.BaseCommand{
+							Group: &v1.CommandGroup{
+								Kind:      kind,
+								IsDefault: isDefault,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	parentCommands := []v1.Command{
+		getCommand("parentbuild", v1.BuildCommandGroupKind, true),
+		getCommand("parentrun", v1.RunCommandGroupKind, true),
+	}
+	localCommands := []v1.Command{
+		getCommand("localbuild", v1.BuildCommandGroupKind, true),
+		getCommand("localrun", v1.RunCommandGroupKind, false),
+	}
+
+	commands := getParentCommands(parentCommands, localCommands)
+	want := []v1.Command{
+		getCommand("parentbuild", v1.BuildCommandGroupKind, false),
+		getCommand("parentrun", v1.RunCommandGroupKind, true),
+	}
+	if !reflect.DeepEqual(commands, want) {
+		t.Errorf("wanted: %v, got: %v, difference at %v", want, commands, pretty.Compare(commands, want))
+	}
+	if !parentCommands[0].Exec.Group.IsDefault {
+		t.Errorf("the parent commands should not be modified")
+	}
+}
@@ -1,7 +1,18 @@
 package parser
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	devfileCtx "github.com/devfile/library/pkg/devfile/parser/context"
+	"github.com/devfile/library/pkg/devfile/parser/data"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/devfile/library/pkg/testingutil/filesystem"
 
 	"sigs.k8s.io/yaml"
 
@@ -9,18 +20,29 @@ import (
 	"k8s.io/klog"
 )
 
+var (
+	// lockTimeout is the time waited for the lock of a devfile held by another writer
+	lockTimeout = 10 * time.Second
+	// lockRetryInterval is the interval between the attempts to take the lock of a devfile
+	lockRetryInterval = 50 * time.Millisecond
+	// staleLockAge is the age after which the lock of a devfile is considered left over by a crashed writer
+	staleLockAge = time.Minute
+)
+
 // WriteJsonDevfile creates a devfile.json file
 func (d *DevfileObj) WriteJsonDevfile() error {
+	if d.inEdit {
+		return nil
+	}
 
 	// Encode data into JSON format
-	jsonData, err := json.MarshalIndent(d.Data, "", "  ")
+	jsonData, err := marshalDevfile(d.Data, true)
 	if err != nil {
-		return errors.Wrapf(err, "failed to marshal devfile object into json")
+		return err
 	}
 
 	// Write to devfile.json
-	fs := d.Ctx.GetFs()
-	err = fs.WriteFile(d.Ctx.GetAbsPath(), jsonData, 0644)
+	err = d.writeDevfile(jsonData)
 	if err != nil {
 		return errors.Wrapf(err, "failed to create devfile json file")
 	}
@@ -32,16 +54,18 @@ func (d *DevfileObj) WriteJsonDevfile() error {
 
 // WriteYamlDevfile creates a devfile.yaml file
 func (d *DevfileObj) WriteYamlDevfile() error {
+	if d.inEdit {
+		return nil
+	}
 
 	// Encode data into YAML format
-	yamlData, err := yaml.Marshal(d.Data)
+	yamlData, err := marshalDevfile(d.Data, false)
 	if err != nil {
-		return errors.Wrapf(err, "failed to marshal devfile object into yaml")
+		return err
 	}
 
 	// Write to devfile.yaml
-	fs := d.Ctx.GetFs()
-	err = fs.WriteFile(d.Ctx.GetAbsPath(), yamlData, 0644)
+	err = d.writeDevfile(yamlData)
 	if err != nil {
 		return errors.Wrapf(err, "failed to create devfile yaml file")
 	}
@@ -50,3 +74,182 @@ func (d *DevfileObj) WriteYamlDevfile() error {
 	klog.V(2).Infof("devfile yaml created at: '%s'", OutputDevfileYamlPath)
 	return nil
 }
+
+// marshalDevfile returns the JSON or the YAML content of the devfile data
+func marshalDevfile(devfileData data.DevfileData, isJSON bool) ([]byte, error) {
+	if isJSON {
+		content, err := json.MarshalIndent(devfileData, "", "  ")
+		return content, errors.Wrapf(err, "failed to marshal devfile object into json")
+	}
+	content, err := yaml.Marshal(devfileData)
+	return content, errors.Wrapf(err, "failed to marshal devfile object into yaml")
+}
+
+// isJSONDevfile returns true if the devfile at the devfile path is in the JSON format, from its content if it exists
+// or from its extension
+func (d *DevfileObj) isJSONDevfile() bool {
+	path := d.Ctx.GetAbsPath()
+	if fs := d.Ctx.GetFs(); fs != nil {
+		if content, err := fs.ReadFile(path); err == nil && len(bytes.TrimSpace(content)) > 0 {
+			return bytes.HasPrefix(bytes.TrimSpace(content), []byte("{"))
+		}
+	}
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// writeDevfile writes the content to the devfile path atomically while holding the lock of the devfile.
+// If the devfile content changed since it was parsed or last written, error out with a DevfileConflictError
+func (d *DevfileObj) writeDevfile(content []byte) error {
+	fs := d.Ctx.GetFs()
+	path := d.Ctx.GetAbsPath()
+
+	unlock, err := lockFile(fs, path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if hash := d.Ctx.GetContentHash(); hash != "" {
+		current, err := fs.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err == nil && devfileCtx.HashContent(current) != hash {
+			return &common.DevfileConflictError{Path: path}
+		}
+	}
+
+	// the written devfile keeps the permissions of the existing devfile
+	perm := os.FileMode(0644)
+	if info, err := fs.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := writeFileAtomically(fs, path, content, perm); err != nil {
+		return err
+	}
+	d.Ctx.SetContentHash(content)
+	return nil
+}
+
+// createDevfile creates the devfile at the devfile path with the content while holding the lock of the devfile.
+// If a file already exists at the path, error out
+func (d *DevfileObj) createDevfile(content []byte) error {
+	fs := d.Ctx.GetFs()
+	path := d.Ctx.GetAbsPath()
+
+	unlock, err := lockFile(fs, path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	file, err := fs.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if os.IsExist(err) {
+		return fmt.Errorf("unable to create the devfile %s, the file already exists", path)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(content)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = fs.Remove(path)
+		return errors.Wrapf(err, "failed to create devfile yaml file")
+	}
+	d.Ctx.SetContentHash(content)
+	return nil
+}
+
+// lockFile takes the advisory lock of the file, a lock file next to it, and returns the function releasing the lock.
+// A lock older than staleLockAge is removed with removeStaleLock, and if the lock is not released before lockTimeout, error out
+func lockFile(fs filesystem.Filesystem, path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		lock, err := fs.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			if err := lock.Close(); err != nil {
+				return nil, err
+			}
+			return func() {
+				if err := fs.Remove(lockPath); err != nil {
+					klog.V(2).Infof("failed to remove the lock %s: %v", lockPath, err)
+				}
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		if info, err := fs.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLockAge && removeStaleLock(fs, lockPath) {
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for the lock %s held by another writer", lockPath)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// removeStaleLock removes the lock if it is still older than staleLockAge and returns true if it was removed.
+// The check and the removal are done while holding a guard lock, so that writers finding the same stale lock
+// cannot remove the lock taken by one of them in the meantime. A guard left over by a crashed writer is removed
+func removeStaleLock(fs filesystem.Filesystem, lockPath string) bool {
+	guardPath := lockPath + ".stale"
+	guard, err := fs.OpenFile(guardPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if info, err := fs.Stat(guardPath); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			klog.V(2).Infof("removing the stale guard %s", guardPath)
+			_ = fs.Remove(guardPath)
+		}
+		return false
+	}
+	_ = guard.Close()
+	defer func() {
+		if err := fs.Remove(guardPath); err != nil {
+			klog.V(2).Infof("failed to remove the guard %s: %v", guardPath, err)
+		}
+	}()
+
+	info, err := fs.Stat(lockPath)
+	if err != nil || time.Since(info.ModTime()) <= staleLockAge {
+		return false
+	}
+	klog.V(2).Infof("removing the stale lock %s", lockPath)
+	return fs.Remove(lockPath) == nil
+}
+
+// writeFileAtomically writes the data to a temp file with the permissions next to the file and renames the temp file
+// into place, so that the file is either left unchanged or fully written
+func writeFileAtomically(fs filesystem.Filesystem, path string, data []byte, perm os.FileMode) error {
+	tempPath := filepath.Join(filepath.Dir(path), fmt.Sprintf(".%s.%d.tmp", filepath.Base(path), time.Now().UnixNano()))
+	tempFile, err := fs.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+
+	_, err = tempFile.Write(data)
+	if err == nil {
+		// the permissions of the created file are restricted by the umask
+		err = fs.Chmod(tempPath, perm)
+	}
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = fs.Rename(tempPath, path)
+	}
+	if err != nil {
+		_ = fs.Remove(tempPath)
+		return err
+	}
+	return nil
+}
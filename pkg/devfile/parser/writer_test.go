@@ -1,13 +1,21 @@
 package parser
 
 import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
 	devfilepkg "github.com/devfile/api/pkg/devfile"
 	devfileCtx "github.com/devfile/library/pkg/devfile/parser/context"
 	v2 "github.com/devfile/library/pkg/devfile/parser/data/v2"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 	"github.com/devfile/library/pkg/testingutil/filesystem"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
 )
 
 func TestWriteJsonDevfile(t *testing.T) {
@@ -79,3 +87,265 @@ func TestWriteJsonDevfile(t *testing.T) {
 		}
 	})
 }
+
+func TestWriteDevfileConcurrency(t *testing.T) {
+
+	content := []byte("schemaVersion: 2.0.0\nmetadata:\n  name: nodejs\n")
+
+	getDevfileObj := func(fs filesystem.Filesystem) DevfileObj {
+		ctx := devfileCtx.FakeContext(fs, OutputDevfileYamlPath)
+		if err := ctx.SetDevfileContent(); err != nil {
+			t.Fatalf("unexpected error: '%v'", err)
+		}
+		return DevfileObj{
+			Ctx: ctx,
+			Data: &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevfileHeader: devfilepkg.DevfileHeader{
+						SchemaVersion: "2.0.0",
+						Metadata: devfilepkg.DevfileMetadata{
+							Name: "nodejs",
+						},
+					},
+				},
+			},
+		}
+	}
+
+	defaultLockTimeout := lockTimeout
+	lockTimeout = 200 * time.Millisecond
+	defer func() { lockTimeout = defaultLockTimeout }()
+
+	tests := []struct {
+		name         string
+		write        func(fs filesystem.Filesystem) error
+		wantConflict bool
+		wantErr      bool
+		wantName     string
+	}{
+		{
+			name: "case 1: successive writes of the same devfile",
+			write: func(fs filesystem.Filesystem) error {
+				d := getDevfileObj(fs)
+				if err := d.SetMetadataName("express"); err != nil {
+					return err
+				}
+				return d.SetMetadataName("koa")
+			},
+			wantName: "koa",
+		},
+		{
+			name: "case 2: the devfile was changed since it was parsed",
+			write: func(fs filesystem.Filesystem) error {
+				d := getDevfileObj(fs)
+				if err := fs.WriteFile(OutputDevfileYamlPath, []byte("schemaVersion: 2.0.0\nmetadata:\n  name: other\n"), 0644); err != nil {
+					return err
+				}
+				return d.SetMetadataName("express")
+			},
+			wantConflict: true,
+			wantErr:      true,
+			wantName:     "other",
+		},
+		{
+			name: "case 3: concurrent writes of devfiles parsed at the same time",
+			write: func(fs filesystem.Filesystem) error {
+				devfiles := []DevfileObj{getDevfileObj(fs), getDevfileObj(fs)}
+				errs := make(chan error, len(devfiles))
+				for _, d := range devfiles {
+					go func(d DevfileObj) {
+						errs <- d.SetMetadataName("express")
+					}(d)
+				}
+				var conflicts int
+				for range devfiles {
+					err := <-errs
+					if _, ok := errors.Cause(err).(*common.DevfileConflictError); ok {
+						conflicts++
+					} else if err != nil {
+						return err
+					}
+				}
+				if conflicts != 1 {
+					return fmt.Errorf("expected exactly one conflict, got %d", conflicts)
+				}
+				return nil
+			},
+			wantName: "express",
+		},
+		{
+			name: "case 4: the devfile is locked by another writer",
+			write: func(fs filesystem.Filesystem) error {
+				if err := fs.WriteFile(OutputDevfileYamlPath+".lock", nil, 0644); err != nil {
+					return err
+				}
+				d := getDevfileObj(fs)
+				return d.SetMetadataName("express")
+			},
+			wantErr:  true,
+			wantName: "nodejs",
+		},
+		{
+			name: "case 5: the devfile permissions are kept",
+			write: func(fs filesystem.Filesystem) error {
+				if err := fs.Chmod(OutputDevfileYamlPath, 0600); err != nil {
+					return err
+				}
+				d := getDevfileObj(fs)
+				if err := d.SetMetadataName("express"); err != nil {
+					return err
+				}
+				info, err := fs.Stat(OutputDevfileYamlPath)
+				if err != nil {
+					return err
+				}
+				if info.Mode().Perm() != 0600 {
+					return fmt.Errorf("expected the devfile permissions 0600, got %o", info.Mode().Perm())
+				}
+				return nil
+			},
+			wantName: "express",
+		},
+		{
+			name: "case 6: the devfile lock is stale",
+			write: func(fs filesystem.Filesystem) error {
+				if err := fs.WriteFile(OutputDevfileYamlPath+".lock", nil, 0644); err != nil {
+					return err
+				}
+				lockTime := time.Now().Add(-2 * staleLockAge)
+				if err := fs.Chtimes(OutputDevfileYamlPath+".lock", lockTime, lockTime); err != nil {
+					return err
+				}
+				d := getDevfileObj(fs)
+				return d.SetMetadataName("express")
+			},
+			wantName: "express",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := filesystem.NewFakeFs()
+			if err := fs.WriteFile(OutputDevfileYamlPath, content, 0644); err != nil {
+				t.Fatalf("unexpected error: '%v'", err)
+			}
+
+			err := tt.write(fs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestWriteDevfileConcurrency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, ok := errors.Cause(err).(*common.DevfileConflictError); ok != tt.wantConflict {
+				t.Errorf("TestWriteDevfileConcurrency() expected a conflict: %v, got %v", tt.wantConflict, err)
+			}
+
+			written, err := fs.ReadFile(OutputDevfileYamlPath)
+			if err != nil {
+				t.Fatalf("unexpected error: '%v'", err)
+			}
+			var d v2.DevfileV2
+			if err := yaml.Unmarshal(written, &d); err != nil {
+				t.Fatalf("unexpected error: '%v'", err)
+			}
+			if d.Metadata.Name != tt.wantName {
+				t.Errorf("TestWriteDevfileConcurrency() metadata name mismatch - wanted: %s, got: %s", tt.wantName, d.Metadata.Name)
+			}
+		})
+	}
+}
+
+// slowStatFs returns the file infos late, so that the writers finding a stale lock act on it after each other
+type slowStatFs struct {
+	filesystem.Filesystem
+}
+
+func (fs slowStatFs) Stat(name string) (os.FileInfo, error) {
+	info, err := fs.Filesystem.Stat(name)
+	time.Sleep(5 * time.Millisecond)
+	return info, err
+}
+
+func TestLockFileStaleConcurrency(t *testing.T) {
+
+	defaultLockRetryInterval := lockRetryInterval
+	lockRetryInterval = time.Millisecond
+	defer func() { lockRetryInterval = defaultLockRetryInterval }()
+
+	fs := slowStatFs{Filesystem: filesystem.NewFakeFs()}
+	lockPath := OutputDevfileYamlPath + ".lock"
+	if err := fs.WriteFile(lockPath, nil, 0644); err != nil {
+		t.Fatalf("unexpected error: '%v'", err)
+	}
+	lockTime := time.Now().Add(-2 * staleLockAge)
+	if err := fs.Chtimes(lockPath, lockTime, lockTime); err != nil {
+		t.Fatalf("unexpected error: '%v'", err)
+	}
+
+	// the writers finding the same stale lock must still hold the lock one at a time
+	var holders, maxHolders int32
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := lockFile(fs, OutputDevfileYamlPath)
+			if err != nil {
+				errs <- err
+				return
+			}
+			current := atomic.AddInt32(&holders, 1)
+			for {
+				max := atomic.LoadInt32(&maxHolders)
+				if current <= max || atomic.CompareAndSwapInt32(&maxHolders, max, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&holders, -1)
+			unlock()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("TestLockFileStaleConcurrency() unexpected error %v", err)
+	}
+	if maxHolders != 1 {
+		t.Errorf("TestLockFileStaleConcurrency() the lock was held by %d writers at the same time", maxHolders)
+	}
+}
+
+func TestWriteDevfileSharedContentHash(t *testing.T) {
+
+	fs := filesystem.NewFakeFs()
+	path := "/project/devfile.yaml"
+	ctx := devfileCtx.NewDevfileCtxWithFs(path, fs)
+	if err := ctx.SetAbsPath(); err != nil {
+		t.Fatalf("unexpected error: '%v'", err)
+	}
+	d := DevfileObj{
+		Ctx: ctx,
+		Data: &v2.DevfileV2{
+			Devfile: v1.Devfile{
+				DevfileHeader: devfilepkg.DevfileHeader{
+					SchemaVersion: "2.0.0",
+					Metadata: devfilepkg.DevfileMetadata{
+						Name: "nodejs",
+					},
+				},
+			},
+		},
+	}
+
+	// the devfile is created in memory, the copy passed to the configurable shares the hash of the written content
+	if err := d.SetMetadataName("express"); err != nil {
+		t.Fatalf("unexpected error: '%v'", err)
+	}
+	if err := fs.WriteFile(path, []byte("schemaVersion: 2.0.0\nmetadata:\n  name: other\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: '%v'", err)
+	}
+	err := d.SetMetadataName("koa")
+	if _, ok := errors.Cause(err).(*common.DevfileConflictError); !ok {
+		t.Errorf("TestWriteDevfileSharedContentHash() expected a conflict, got %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+package project
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/devfile/library/pkg/util"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+)
+
+// gitDir is the directory of the git repository of a cloned starter project, which is not copied
+const gitDir = ".git"
+
+// DownloadOptions are the options of DownloadStarterProject
+type DownloadOptions struct {
+	// Force downloads the starter project in a non empty destination, the existing files are overwritten
+	Force bool
+	// GitClient clones the git and github starter projects, ExecGitClient if nil
+	GitClient GitClient
+}
+
+// DownloadStarterProject downloads the starter project with the name of the devfile in the dest directory, the only
+// starter project of the devfile is downloaded if the name is empty. Only the subDir of the starter project is
+// downloaded if set, and the devfile of the starter project is skipped so that it does not overwrite the devfile.
+// The dest directory is created if it does not exist, and must be empty or contain only the devfile unless forced
+func DownloadStarterProject(devfileObj parser.DevfileObj, name, dest string, opts DownloadOptions) error {
+	starterProject, err := getStarterProject(devfileObj, name)
+	if err != nil {
+		return err
+	}
+	devfileNames := getDevfileNames(devfileObj)
+	if err := checkDestination(dest, devfileNames, opts.Force); err != nil {
+		return err
+	}
+
+	tmpDir, err := ioutil.TempDir("", "starter-project")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create a temporary directory for the starter project %s", starterProject.Name)
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			klog.Errorf("Could not delete temporary directory for starter project. Error: %s", err)
+		}
+	}()
+
+	if err := fetchStarterProject(starterProject, tmpDir, opts); err != nil {
+		return errors.Wrapf(err, "failed to download the starter project %s", starterProject.Name)
+	}
+
+	src := tmpDir
+	if starterProject.SubDir != "" {
+		subDir := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(starterProject.SubDir, "/")))
+		if isOutside(".", subDir) {
+			return fmt.Errorf("subDir %s of the starter project %s is outside of the starter project", starterProject.SubDir, starterProject.Name)
+		}
+		src, err = filepath.EvalSymlinks(filepath.Join(tmpDir, subDir))
+		if err != nil {
+			return fmt.Errorf("subDir %s of the starter project %s is not found", starterProject.SubDir, starterProject.Name)
+		}
+		if root, err := filepath.EvalSymlinks(tmpDir); err != nil || isOutside(root, src) {
+			return fmt.Errorf("subDir %s of the starter project %s is outside of the starter project", starterProject.SubDir, starterProject.Name)
+		}
+		if info, err := os.Stat(src); err != nil || !info.IsDir() {
+			return fmt.Errorf("subDir %s of the starter project %s is not a directory", starterProject.SubDir, starterProject.Name)
+		}
+	}
+	return copyStarterProject(src, dest, devfileNames)
+}
+
+// getStarterProject returns the starter project with the name of the devfile, or the only starter project if the name is empty
+func getStarterProject(devfileObj parser.DevfileObj, name string) (v1.StarterProject, error) {
+	starterProjects, err := devfileObj.Data.GetStarterProjects(common.DevfileOptions{})
+	if err != nil {
+		return v1.StarterProject{}, err
+	}
+	if name == "" {
+		if len(starterProjects) != 1 {
+			return v1.StarterProject{}, fmt.Errorf("the devfile has %d starter projects, the name of the starter project is required", len(starterProjects))
+		}
+		return starterProjects[0], nil
+	}
+	for _, starterProject := range starterProjects {
+		if starterProject.Name == name {
+			return starterProject, nil
+		}
+	}
+	return v1.StarterProject{}, &common.FieldNotFoundError{Field: "starterProject", Name: name}
+}
+
+// getDevfileNames returns the file names of the devfile, which are not downloaded from the starter projects
+func getDevfileNames(devfileObj parser.DevfileObj) map[string]bool {
+	names := map[string]bool{
+		parser.OutputDevfileYamlPath:       true,
+		"." + parser.OutputDevfileYamlPath: true,
+	}
+	if absPath := devfileObj.Ctx.GetAbsPath(); absPath != "" {
+		names[filepath.Base(absPath)] = true
+	}
+	return names
+}
+
+// checkDestination creates the dest directory if it does not exist, and checks that it contains only the devfile
+// if it exists and the download is not forced
+func checkDestination(dest string, devfileNames map[string]bool, force bool) error {
+	files, err := ioutil.ReadDir(dest)
+	if os.IsNotExist(err) {
+		return os.MkdirAll(dest, os.ModePerm)
+	}
+	if err != nil {
+		return err
+	}
+	if force {
+		return nil
+	}
+	for _, file := range files {
+		if file.IsDir() || !devfileNames[file.Name()] {
+			return errors.Errorf("Folder %s is not empty. It can only contain the devfile used.", dest)
+		}
+	}
+	return nil
+}
+
+// fetchStarterProject downloads the sources of the starter project in the dir directory
+func fetchStarterProject(starterProject v1.StarterProject, dir string, opts DownloadOptions) error {
+	switch {
+	case starterProject.Git != nil:
+		return cloneStarterProject(starterProject.Git.GitLikeProjectSource, dir, opts.GitClient)
+	case starterProject.Github != nil:
+		return cloneStarterProject(starterProject.Github.GitLikeProjectSource, dir, opts.GitClient)
+	case starterProject.Zip != nil:
+		return util.GetAndExtractZip(starterProject.Zip.Location, dir, "")
+	default:
+		return fmt.Errorf("unsupported source of the starter project, only git, github and zip sources are supported")
+	}
+}
+
+func cloneStarterProject(source v1.GitLikeProjectSource, dir string, gitClient GitClient) error {
+	_, url, revision, err := common.GetDefaultSource(source)
+	if err != nil {
+		return err
+	}
+	if url == "" {
+		return fmt.Errorf("no git remote defined")
+	}
+	if gitClient == nil {
+		gitClient = ExecGitClient{}
+	}
+	if err := gitClient.Clone(url, revision, dir); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(dir, gitDir))
+}
+
+// copyStarterProject copies the files of the src directory in the dest directory, except the devfile at the root
+// of the src directory. The symbolic links are copied if they are relative and point inside the src directory
+func copyStarterProject(src, dest string, devfileNames map[string]bool) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+		if devfileNames[relPath] && !info.IsDir() {
+			klog.V(4).Infof("skipping the devfile %s of the starter project", relPath)
+			return nil
+		}
+
+		destPath := filepath.Join(dest, relPath)
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(destPath, os.ModePerm)
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if filepath.IsAbs(target) || isOutside(src, filepath.Join(filepath.Dir(path), target)) {
+				return fmt.Errorf("symbolic link %s of the starter project points outside of the starter project", relPath)
+			}
+			if err := os.Remove(destPath); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return os.Symlink(target, destPath)
+		default:
+			return util.CopyFile(path, destPath, info)
+		}
+	})
+}
+
+// isOutside returns true if the path is outside of the dir directory
+func isOutside(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator))
+}
@@ -0,0 +1,264 @@
+package project
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+)
+
+// fakeGitClient clones a repository by writing its files
+type fakeGitClient struct {
+	files    map[string]string
+	symlinks map[string]string
+	url      string
+	revision string
+}
+
+func (c *fakeGitClient) Clone(url, revision, dest string) error {
+	c.url, c.revision = url, revision
+	for name, content := range c.files {
+		path := filepath.Join(dest, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	for name, target := range c.symlinks {
+		if err := os.Symlink(target, filepath.Join(dest, filepath.FromSlash(name))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestDownloadStarterProject(t *testing.T) {
+
+	repoFiles := map[string]string{
+		".git/HEAD":            "ref: refs/heads/main",
+		"devfile.yaml":         "schemaVersion: 2.0.0",
+		"package.json":         "{}",
+		"src/server.js":        "listen(3000)",
+		"backend/devfile.yaml": "schemaVersion: 2.0.0",
+		"backend/main.go":      "package main",
+	}
+	gitSource := v1.GitLikeProjectSource{
+		Remotes:      map[string]string{"origin": "https://github.com/odo-devfiles/nodejs-ex"},
+		CheckoutFrom: &v1.CheckoutFrom{Revision: "v1.0.0"},
+	}
+	starterProjects := []v1.StarterProject{
+		{
+			Name:          "git-starter",
+			ProjectSource: v1.ProjectSource{Git: &v1.GitProjectSource{GitLikeProjectSource: gitSource}},
+		},
+		{
+			Name:          "github-starter",
+			SubDir:        "/backend",
+			ProjectSource: v1.ProjectSource{Github: &v1.GithubProjectSource{GitLikeProjectSource: gitSource}},
+		},
+		{
+			Name:          "zip-starter",
+			ProjectSource: v1.ProjectSource{Zip: &v1.ZipProjectSource{}},
+		},
+		{
+			Name:          "escaping-starter",
+			SubDir:        "../",
+			ProjectSource: v1.ProjectSource{Git: &v1.GitProjectSource{GitLikeProjectSource: gitSource}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		starter       string
+		existingFiles []string
+		force         bool
+		symlinks      map[string]string
+		flatZip       bool
+		wantFiles     []string
+		wantErr       bool
+		wantNotFound  bool
+	}{
+		{
+			name:      "case 1: git starter project",
+			starter:   "git-starter",
+			wantFiles: []string{"backend", "backend/devfile.yaml", "backend/main.go", "devfile.yaml", "package.json", "src", "src/server.js"},
+		},
+		{
+			name:      "case 2: github starter project with a subDir",
+			starter:   "github-starter",
+			wantFiles: []string{"devfile.yaml", "main.go"},
+		},
+		{
+			name:      "case 3: zip starter project",
+			starter:   "zip-starter",
+			wantFiles: []string{"backend", "backend/devfile.yaml", "backend/main.go", "devfile.yaml", "package.json", "src", "src/server.js"},
+		},
+		{
+			name:          "case 4: non empty destination",
+			starter:       "git-starter",
+			existingFiles: []string{"README.md"},
+			wantErr:       true,
+		},
+		{
+			name:          "case 5: non empty destination with force",
+			starter:       "github-starter",
+			existingFiles: []string{"README.md"},
+			force:         true,
+			wantFiles:     []string{"README.md", "devfile.yaml", "main.go"},
+		},
+		{
+			name:         "case 6: missing starter project",
+			starter:      "java-starter",
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name:    "case 7: subDir outside of the starter project",
+			starter: "escaping-starter",
+			wantErr: true,
+		},
+		{
+			name:     "case 8: symbolic link inside the starter project",
+			starter:  "git-starter",
+			symlinks: map[string]string{"src/index.js": "server.js"},
+			wantFiles: []string{"backend", "backend/devfile.yaml", "backend/main.go", "devfile.yaml", "package.json", "src",
+				"src/index.js", "src/server.js"},
+		},
+		{
+			name:     "case 9: symbolic link outside of the starter project",
+			starter:  "git-starter",
+			symlinks: map[string]string{"src/passwd": "../../../etc/passwd"},
+			wantErr:  true,
+		},
+		{
+			name:    "case 10: starter project name required",
+			wantErr: true,
+		},
+		{
+			name:      "case 11: zip starter project without a root directory",
+			starter:   "zip-starter",
+			flatZip:   true,
+			wantFiles: []string{"backend", "backend/devfile.yaml", "backend/main.go", "devfile.yaml", "package.json", "src", "src/server.js"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "download-starter-project")
+			if err != nil {
+				t.Fatalf("TestDownloadStarterProject() unexpected error %v", err)
+			}
+			defer os.RemoveAll(dir)
+
+			dest := filepath.Join(dir, "project")
+			if err := os.MkdirAll(dest, os.ModePerm); err != nil {
+				t.Fatalf("TestDownloadStarterProject() unexpected error %v", err)
+			}
+			for _, name := range append([]string{"devfile.yaml"}, tt.existingFiles...) {
+				if err := ioutil.WriteFile(filepath.Join(dest, name), []byte(name), 0644); err != nil {
+					t.Fatalf("TestDownloadStarterProject() unexpected error %v", err)
+				}
+			}
+			zipStarter := starterProjects[2]
+			rootDir := "nodejs-ex-main/"
+			if tt.flatZip {
+				rootDir = ""
+			}
+			zipStarter.Zip = &v1.ZipProjectSource{Location: "file://" + writeZip(t, dir, rootDir, repoFiles)}
+
+			devfileObj, err := parser.NewDevfile(filepath.Join(dest, "devfile.yaml"), "2.0.0").
+				WithMetadata("nodejs", "").
+				AddStarterProject(starterProjects[0]).
+				AddStarterProject(starterProjects[1]).
+				AddStarterProject(zipStarter).
+				AddStarterProject(starterProjects[3]).
+				Build()
+			if err != nil {
+				t.Fatalf("TestDownloadStarterProject() unexpected error %v", err)
+			}
+
+			gitClient := &fakeGitClient{files: repoFiles, symlinks: tt.symlinks}
+			err = DownloadStarterProject(devfileObj, tt.starter, dest, DownloadOptions{Force: tt.force, GitClient: gitClient})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TestDownloadStarterProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, ok := err.(*common.FieldNotFoundError); ok != tt.wantNotFound {
+				t.Errorf("TestDownloadStarterProject() expected a FieldNotFoundError: %v, got %v", tt.wantNotFound, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if gitClient.url != "" && (gitClient.url != gitSource.Remotes["origin"] || gitClient.revision != "v1.0.0") {
+				t.Errorf("TestDownloadStarterProject() clone mismatch - got url: %s, revision: %s", gitClient.url, gitClient.revision)
+			}
+			if gotFiles := listFiles(t, dest); !reflect.DeepEqual(gotFiles, tt.wantFiles) {
+				t.Errorf("TestDownloadStarterProject() files mismatch - wanted: %v, got: %v", tt.wantFiles, gotFiles)
+			}
+			content, err := ioutil.ReadFile(filepath.Join(dest, "devfile.yaml"))
+			if err != nil || string(content) != "devfile.yaml" {
+				t.Errorf("TestDownloadStarterProject() the devfile was overwritten: %s", content)
+			}
+		})
+	}
+}
+
+// writeZip writes a zip archive with the files in the rootDir directory, as the archives of the git providers,
+// or at the root of the archive if rootDir is empty
+func writeZip(t *testing.T, dir, rootDir string, files map[string]string) string {
+	path := filepath.Join(dir, "starter.zip")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("writeZip() unexpected error %v", err)
+	}
+	defer file.Close()
+
+	w := zip.NewWriter(file)
+	for name, content := range files {
+		if filepath.Dir(name) == ".git" {
+			continue
+		}
+		f, err := w.Create(rootDir + name)
+		if err != nil {
+			t.Fatalf("writeZip() unexpected error %v", err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatalf("writeZip() unexpected error %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("writeZip() unexpected error %v", err)
+	}
+	return path
+}
+
+// listFiles returns the sorted slash separated paths of the files and directories in the dir directory
+func listFiles(t *testing.T, dir string) []string {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir {
+			relPath, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(relPath))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("listFiles() unexpected error %v", err)
+	}
+	sort.Strings(files)
+	return files
+}
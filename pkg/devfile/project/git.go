@@ -0,0 +1,69 @@
+package project
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+)
+
+// GitClient clones the git repositories of the starter projects
+type GitClient interface {
+	// Clone makes a shallow clone of the repository at the url in the dest directory, checked out at the revision.
+	// The revision is a branch, a tag or a commit, the default branch is checked out if the revision is empty
+	Clone(url, revision, dest string) error
+}
+
+// ExecGitClient is a GitClient running the git command
+type ExecGitClient struct{}
+
+// Clone clones the repository with git clone --depth 1, a commit revision which is not found as a remote branch
+// is fetched in a new repository. A url or a revision starting with a dash is rejected as it would be read as a git option
+func (c ExecGitClient) Clone(url, revision, dest string) error {
+	if strings.HasPrefix(url, "-") {
+		return errors.Errorf("invalid git url %s", url)
+	}
+	if strings.HasPrefix(revision, "-") {
+		return errors.Errorf("invalid git revision %s", revision)
+	}
+
+	args := []string{"clone", "--depth", "1"}
+	if revision != "" {
+		args = append(args, "--branch", revision)
+	}
+	err := runGit(append(args, "--", url, dest)...)
+	if err == nil || revision == "" || !isRemoteBranchNotFound(err) {
+		return err
+	}
+
+	klog.V(4).Infof("revision %s of %s is not a branch or a tag, fetching it as a commit", revision, url)
+	for _, args := range [][]string{
+		{"init", "--quiet", "--", dest},
+		{"-C", dest, "remote", "add", "--", "origin", url},
+		{"-C", dest, "fetch", "--quiet", "--depth", "1", "origin", "--", revision},
+		{"-C", dest, "checkout", "--quiet", "FETCH_HEAD"},
+	} {
+		if err := runGit(args...); err != nil {
+			return errors.Wrapf(err, "failed to clone %s at revision %s", url, revision)
+		}
+	}
+	return nil
+}
+
+// isRemoteBranchNotFound returns true if git clone failed because the revision is not a branch or a tag of the repository
+func isRemoteBranchNotFound(err error) bool {
+	return strings.Contains(err.Error(), "Remote branch") && strings.Contains(err.Error(), "not found in upstream")
+}
+
+func runGit(args ...string) error {
+	cmd := exec.Command("git", args...) // #nosec G204
+	// the git messages are not translated, to find the error of a missing remote branch
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "git %s failed: %s", strings.Join(args, " "), strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExecGitClientClone(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "gitclient")
+	if err != nil {
+		t.Fatalf("TestExecGitClientClone() unexpected error %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	tests := []struct {
+		name     string
+		url      string
+		revision string
+	}{
+		{
+			name:     "case 1: url starting with a dash",
+			url:      "--upload-pack=touch " + filepath.Join(tempDir, "injected"),
+			revision: "main",
+		},
+		{
+			name:     "case 2: revision starting with a dash",
+			url:      "https://github.com/devfile/library.git",
+			revision: "--upload-pack=touch " + filepath.Join(tempDir, "injected"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ExecGitClient{}.Clone(tt.url, tt.revision, filepath.Join(tempDir, "dest"))
+			if err == nil {
+				t.Errorf("TestExecGitClientClone() expected an error for url %q and revision %q", tt.url, tt.revision)
+			}
+			if _, err := os.Stat(filepath.Join(tempDir, "injected")); !os.IsNotExist(err) {
+				t.Errorf("TestExecGitClientClone() the git option of the argument was run")
+			}
+		})
+	}
+}
+
+func TestExecGitClientCloneRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	tempDir, err := ioutil.TempDir("", "gitclient")
+	if err != nil {
+		t.Fatalf("TestExecGitClientCloneRevision() unexpected error %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// a bare repository with two commits on the main branch, the first commit is not the head of a branch
+	remoteDir := filepath.Join(tempDir, "remote.git")
+	workDir := filepath.Join(tempDir, "work")
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=devfile", "-c", "user.email=devfile@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("TestExecGitClientCloneRevision() git %s failed: %v: %s", strings.Join(args, " "), err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	git("init", "--quiet", "--bare", remoteDir)
+	git("-C", remoteDir, "config", "uploadpack.allowAnySHA1InWant", "true")
+	git("init", "--quiet", workDir)
+	git("-C", workDir, "checkout", "--quiet", "-b", "main")
+	git("-C", workDir, "commit", "--quiet", "--allow-empty", "-m", "first")
+	firstCommit := git("-C", workDir, "rev-parse", "HEAD")
+	git("-C", workDir, "commit", "--quiet", "--allow-empty", "-m", "second")
+	secondCommit := git("-C", workDir, "rev-parse", "HEAD")
+	git("-C", workDir, "push", "--quiet", remoteDir, "main")
+
+	tests := []struct {
+		name     string
+		revision string
+		wantHead string
+	}{
+		{
+			name:     "case 1: commit revision",
+			revision: firstCommit,
+			wantHead: firstCommit,
+		},
+		{
+			name:     "case 2: branch revision",
+			revision: "main",
+			wantHead: secondCommit,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := filepath.Join(tempDir, fmt.Sprintf("dest%d", i))
+			if err := (ExecGitClient{}).Clone("file://"+filepath.ToSlash(remoteDir), tt.revision, dest); err != nil {
+				t.Fatalf("TestExecGitClientCloneRevision() unexpected error %v", err)
+			}
+			if head := git("-C", dest, "rev-parse", "HEAD"); head != tt.wantHead {
+				t.Errorf("TestExecGitClientCloneRevision() HEAD mismatch - got: %s, wanted: %s", head, tt.wantHead)
+			}
+		})
+	}
+}
+
+func TestIsRemoteBranchNotFound(t *testing.T) {
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "case 1: missing remote branch",
+			err:  errors.New("git clone --depth 1 --branch 0c50a3e -- https://github.com/devfile/library.git dest failed: Cloning into 'dest'...\nwarning: Could not find remote branch 0c50a3e to clone.\nfatal: Remote branch 0c50a3e not found in upstream origin"),
+			want: true,
+		},
+		{
+			name: "case 2: missing repository",
+			err:  errors.New("git clone --depth 1 --branch main -- https://github.com/devfile/missing.git dest failed: fatal: repository 'https://github.com/devfile/missing.git/' not found"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRemoteBranchNotFound(tt.err); got != tt.want {
+				t.Errorf("TestIsRemoteBranchNotFound() got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
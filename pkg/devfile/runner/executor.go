@@ -0,0 +1,211 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/devfile/library/pkg/devfile/commands"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/pkg/errors"
+)
+
+// Executor runs the command line of the exec steps
+type Executor interface {
+	// Execute runs the command line of the exec step, writing its output to stdout and stderr
+	// a command line exiting with a non zero code returns an error implementing ExitCoder
+	Execute(ctx context.Context, step commands.CommandStep, stdout, stderr io.Writer) error
+}
+
+// ExitCoder is implemented by the errors of the command lines exiting with a non zero code
+type ExitCoder interface {
+	ExitStatus() int
+}
+
+// ExitError error returned if a command line exits with a non zero code
+type ExitError struct {
+	// exit code of the command line
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("command exited with code %d", e.Code)
+}
+
+// ExitStatus returns the exit code of the command line
+func (e *ExitError) ExitStatus() int {
+	return e.Code
+}
+
+// LocalExecutor runs the command lines on the local machine with the shell, in the working dir and with the env of the steps.
+// The working dir and the env of the steps are resolved for the container of their component, the projects root of the
+// container is replaced by the local projects root
+type LocalExecutor struct {
+	// Shell is the shell running the command lines, /bin/sh by default
+	Shell string
+	// ProjectsRoot is the local directory of the projects, replacing the $PROJECTS_ROOT of the containers, the current directory by default
+	ProjectsRoot string
+}
+
+// Execute runs the command line of the exec step on the local machine
+func (e LocalExecutor) Execute(ctx context.Context, step commands.CommandStep, stdout, stderr io.Writer) error {
+	if step.Command.Exec == nil {
+		return fmt.Errorf("the command %s is not an exec command", step.Command.Id)
+	}
+	shell := e.Shell
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+
+	localPath, err := e.getLocalPath(step)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(shell, "-c", step.Command.Exec.CommandLine)
+	cmd.Dir = localPath(step.WorkingDir)
+	cmd.Env = os.Environ()
+	for _, env := range step.Env {
+		value := env.Value
+		if env.Name == common.EnvProjectsRoot || env.Name == common.EnvProjectSource {
+			value = localPath(value)
+		}
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", env.Name, value))
+	}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	setProcessGroup(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// kill the processes started by the command line too, they would keep the output open
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			killProcessGroup(cmd)
+		case <-done:
+		}
+	}()
+
+	err = cmd.Wait()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return &ExitError{Code: exitErr.ExitCode()}
+	}
+	return err
+}
+
+// getLocalPath returns a function returning the local path of a path of the container of the step,
+// a path in the projects root of the container is moved to the local projects root
+func (e LocalExecutor) getLocalPath(step commands.CommandStep) (func(string) string, error) {
+	localRoot := e.ProjectsRoot
+	if localRoot == "" {
+		var err error
+		if localRoot, err = os.Getwd(); err != nil {
+			return nil, err
+		}
+	}
+	sourceMapping := ""
+	if step.Component.Container != nil {
+		sourceMapping = step.Component.Container.SourceMapping
+	}
+	containerRoot := path.Clean(common.GetProjectsRoot(sourceMapping))
+
+	return func(containerPath string) string {
+		switch {
+		case containerPath == "":
+			return ""
+		case path.Clean(containerPath) == containerRoot:
+			return localRoot
+		case strings.HasPrefix(path.Clean(containerPath), containerRoot+"/"):
+			return filepath.Join(localRoot, filepath.FromSlash(strings.TrimPrefix(path.Clean(containerPath), containerRoot+"/")))
+		}
+		return containerPath
+	}, nil
+}
+
+// PodExecClient executes a command in a container of a pod, the client-go remotecommand executor can be used to implement it.
+// A command exiting with a non zero code should return an error implementing ExitCoder, as the client-go CodeExitError does
+type PodExecClient interface {
+	ExecCMDInContainer(ctx context.Context, podName, containerName string, cmd []string, stdout, stderr io.Writer) error
+}
+
+// PodExecutor runs the command lines in the containers of their component
+type PodExecutor struct {
+	Client PodExecClient
+	// PodName returns the name of the pod running the container of the component
+	PodName func(componentName string) (string, error)
+}
+
+// Execute runs the command line of the exec step in the container of its component
+func (e PodExecutor) Execute(ctx context.Context, step commands.CommandStep, stdout, stderr io.Writer) error {
+	if step.Command.Exec == nil {
+		return fmt.Errorf("the command %s is not an exec command", step.Command.Id)
+	}
+	podName, err := e.PodName(step.Component.Name)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get the pod of component %s", step.Component.Name)
+	}
+
+	return e.Client.ExecCMDInContainer(ctx, podName, step.Component.Name, []string{"/bin/sh", "-c", commands.GetExecScript(step.Command.Exec.CommandLine, step.WorkingDir, step.Env)}, stdout, stderr)
+}
+
+// FakeResult is the result of a command line run by the FakeExecutor
+type FakeResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Err      error
+}
+
+// FakeExecutor is an executor for testing, returning the result set for the command ids
+type FakeExecutor struct {
+	// Results are the results of the commands by id, the commands without result succeed without output
+	Results map[string]FakeResult
+
+	mu       sync.Mutex
+	executed []string
+}
+
+// Execute writes the output and returns the result set for the command id of the step
+func (e *FakeExecutor) Execute(ctx context.Context, step commands.CommandStep, stdout, stderr io.Writer) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	e.mu.Lock()
+	e.executed = append(e.executed, step.Command.Id)
+	e.mu.Unlock()
+
+	result := e.Results[step.Command.Id]
+	if _, err := io.WriteString(stdout, result.Stdout); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(stderr, result.Stderr); err != nil {
+		return err
+	}
+	if result.Err != nil {
+		return result.Err
+	}
+	if result.ExitCode != 0 {
+		return &ExitError{Code: result.ExitCode}
+	}
+	return nil
+}
+
+// Executed returns the ids of the commands executed, in order
+func (e *FakeExecutor) Executed() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.executed...)
+}
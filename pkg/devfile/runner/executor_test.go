@@ -0,0 +1,178 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/commands"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/devfile/library/pkg/testingutil"
+)
+
+func getExecStep(commandLine, workingDir string, env []v1.EnvVar) commands.CommandStep {
+	return commands.CommandStep{
+		Command: v1.Command{
+			Id: "cmd",
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					CommandLine: commandLine,
+					Component:   "runtime",
+				},
+			},
+		},
+		Component:  v1.Component{Name: "runtime"},
+		WorkingDir: workingDir,
+		Env:        env,
+	}
+}
+
+func TestLocalExecutorExecute(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "runner")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	tests := []struct {
+		name         string
+		step         commands.CommandStep
+		wantStdout   string
+		wantStderr   string
+		wantExitCode int
+	}{
+		{
+			name:       "Case 1: Command line with env and working dir",
+			step:       getExecStep("echo $MODE && pwd && echo oops >&2", tempDir, []v1.EnvVar{{Name: "MODE", Value: "dev"}}),
+			wantStdout: "dev\n" + tempDir + "\n",
+			wantStderr: "oops\n",
+		},
+		{
+			name:         "Case 2: Command line exiting with a non zero code",
+			step:         getExecStep("exit 3", "", nil),
+			wantExitCode: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			err := LocalExecutor{}.Execute(context.Background(), tt.step, stdout, stderr)
+
+			exitCode := 0
+			if exitCoder, ok := err.(ExitCoder); ok {
+				exitCode = exitCoder.ExitStatus()
+			} else if err != nil {
+				t.Errorf("TestLocalExecutorExecute unexpected error: %v", err)
+			}
+			if exitCode != tt.wantExitCode {
+				t.Errorf("TestLocalExecutorExecute error: exit code mismatch - got: %d, wanted: %d", exitCode, tt.wantExitCode)
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("TestLocalExecutorExecute error: stdout mismatch - got: %q, wanted: %q", stdout.String(), tt.wantStdout)
+			}
+			if stderr.String() != tt.wantStderr {
+				t.Errorf("TestLocalExecutorExecute error: stderr mismatch - got: %q, wanted: %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestLocalExecutorExecuteProjectSource(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "runner")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	// the source of the first project of the test devfile
+	if err := os.Mkdir(filepath.Join(tempDir, "test-project"), 0755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	devObj := parser.DevfileObj{
+		Data: &testingutil.TestDevfileData{
+			Components: []v1.Component{testingutil.GetFakeContainerComponent("runtime")},
+			Commands: []v1.Command{
+				{
+					Id: "pwd",
+					CommandUnion: v1.CommandUnion{
+						Exec: &v1.ExecCommand{
+							CommandLine: "pwd && echo $PROJECTS_ROOT $PROJECT_SOURCE",
+							Component:   "runtime",
+							WorkingDir:  "${PROJECT_SOURCE}",
+						},
+					},
+				},
+			},
+		},
+	}
+	plan, err := commands.GetCommandPlan(devObj, "pwd")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	err = LocalExecutor{ProjectsRoot: tempDir}.Execute(context.Background(), *plan.Step, stdout, ioutil.Discard)
+	if err != nil {
+		t.Fatalf("TestLocalExecutorExecuteProjectSource unexpected error: %v", err)
+	}
+	projectSource := filepath.Join(tempDir, "test-project")
+	wantStdout := projectSource + "\n" + tempDir + " " + projectSource + "\n"
+	if stdout.String() != wantStdout {
+		t.Errorf("TestLocalExecutorExecuteProjectSource error: stdout mismatch - got: %q, wanted: %q", stdout.String(), wantStdout)
+	}
+}
+
+func TestLocalExecutorExecuteCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := LocalExecutor{}.Execute(ctx, getExecStep("sleep 10", "", nil), ioutil.Discard, ioutil.Discard)
+	if err != context.DeadlineExceeded {
+		t.Errorf("TestLocalExecutorExecuteCancelled error: got %v, wanted %v", err, context.DeadlineExceeded)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("TestLocalExecutorExecuteCancelled error: the command was not cancelled")
+	}
+}
+
+type fakePodExecClient struct {
+	podName       string
+	containerName string
+	cmd           []string
+}
+
+func (c *fakePodExecClient) ExecCMDInContainer(ctx context.Context, podName, containerName string, cmd []string, stdout, stderr io.Writer) error {
+	c.podName, c.containerName, c.cmd = podName, containerName, cmd
+	return nil
+}
+
+func TestPodExecutorExecute(t *testing.T) {
+	client := &fakePodExecClient{}
+	executor := PodExecutor{
+		Client: client,
+		PodName: func(componentName string) (string, error) {
+			return "myapp-" + componentName, nil
+		},
+	}
+
+	step := getExecStep("npm test", "/projects/app", []v1.EnvVar{{Name: "MODE", Value: "it's dev"}})
+	if err := executor.Execute(context.Background(), step, ioutil.Discard, ioutil.Discard); err != nil {
+		t.Errorf("TestPodExecutorExecute unexpected error: %v", err)
+	}
+
+	wantCmd := []string{"/bin/sh", "-c", `export MODE='it'\''s dev' && cd "/projects/app" && npm test`}
+	if client.podName != "myapp-runtime" || client.containerName != "runtime" {
+		t.Errorf("TestPodExecutorExecute error: got pod %s and container %s", client.podName, client.containerName)
+	}
+	if !reflect.DeepEqual(client.cmd, wantCmd) {
+		t.Errorf("TestPodExecutorExecute error: command mismatch - got: %v, wanted: %v", client.cmd, wantCmd)
+	}
+}
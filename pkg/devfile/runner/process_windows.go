@@ -0,0 +1,15 @@
+package runner
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on windows
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the process of the command
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		_ = cmd.Process.Kill()
+	}
+}
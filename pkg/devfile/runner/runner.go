@@ -0,0 +1,194 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/devfile/library/pkg/devfile/commands"
+	"github.com/fatih/color"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+)
+
+// Applier applies the components of the apply steps
+type Applier interface {
+	Apply(ctx context.Context, step commands.CommandStep) error
+}
+
+// StepResult is the result of a step of an execution plan
+type StepResult struct {
+	CommandID     string
+	ComponentName string
+	// Ran is false if the step did not run, because a previous step failed or the run was cancelled
+	Ran      bool
+	ExitCode int
+	Err      error
+}
+
+// Runner runs the execution plans of the devfile commands
+type Runner struct {
+	Executor Executor
+	// Applier applies the components of the apply steps, the plans with apply steps fail if it is not set
+	Applier Applier
+	// Out is the writer of the output of the commands, os.Stdout by default
+	Out io.Writer
+}
+
+// Run runs the execution plan and returns the results of its steps, in order. The commands of a composite command run in order
+// and stop at the first failure, unless the composite command is parallel, then they run concurrently and the first failure
+// cancels the commands still running. The output of the commands is prefixed with the command id, an error is returned
+// if a step failed or if the context is cancelled
+func (r Runner) Run(ctx context.Context, plan commands.CommandPlan) ([]StepResult, error) {
+	steps := plan.Steps()
+	results := make([]StepResult, len(steps))
+	for i, step := range steps {
+		results[i] = StepResult{
+			CommandID:     step.Command.Id,
+			ComponentName: step.Component.Name,
+		}
+	}
+
+	out := r.Out
+	if out == nil {
+		out = os.Stdout
+	}
+	err := r.run(ctx, plan, results, &syncWriter{w: out})
+	return results, err
+}
+
+// run runs the plan, results are the results of the steps of the plan
+func (r Runner) run(ctx context.Context, plan commands.CommandPlan, results []StepResult, out *syncWriter) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if plan.Step != nil {
+		return r.runStep(ctx, *plan.Step, &results[0], out)
+	}
+
+	if !plan.Parallel {
+		offset := 0
+		for _, command := range plan.Commands {
+			stepCount := len(command.Steps())
+			if err := r.run(ctx, command, results[offset:offset+stepCount], out); err != nil {
+				return err
+			}
+			offset += stepCount
+		}
+		return nil
+	}
+
+	// a failed branch cancels its sibling branches
+	branchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	errs := make([]error, len(plan.Commands))
+	offset := 0
+	for i, command := range plan.Commands {
+		stepCount := len(command.Steps())
+		wg.Add(1)
+		go func(i int, command commands.CommandPlan, results []StepResult) {
+			defer wg.Done()
+			if errs[i] = r.run(branchCtx, command, results, out); errs[i] != nil {
+				cancel()
+			}
+		}(i, command, results[offset:offset+stepCount])
+		offset += stepCount
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var messages []string
+	for _, err := range errs {
+		// the errors of the cancelled branches are not reported, only the failure that cancelled them
+		if err != nil && errors.Cause(err) != context.Canceled {
+			messages = append(messages, err.Error())
+		}
+	}
+	if len(messages) > 0 {
+		return fmt.Errorf("the parallel commands of %s failed: %s", plan.CommandID, strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// runStep runs the exec or apply step and sets its result
+func (r Runner) runStep(ctx context.Context, step commands.CommandStep, result *StepResult, out *syncWriter) error {
+	klog.V(4).Infof("running command %s of component %s", step.Command.Id, step.Component.Name)
+	result.Ran = true
+
+	var err error
+	switch {
+	case step.Command.Exec != nil && r.Executor == nil:
+		err = fmt.Errorf("no executor is set to run the command line of %s", step.Command.Id)
+	case step.Command.Exec != nil:
+		prefix := fmt.Sprintf("[%s] ", step.Command.Id)
+		stdout := &lineWriter{out: out, prefix: prefix, color: color.New(color.FgYellow)}
+		stderr := &lineWriter{out: out, prefix: prefix, color: color.New(color.FgRed)}
+		err = r.Executor.Execute(ctx, step, stdout, stderr)
+		stdout.Flush()
+		stderr.Flush()
+	case r.Applier == nil:
+		err = fmt.Errorf("no applier is set to apply component %s", step.Component.Name)
+	default:
+		err = r.Applier.Apply(ctx, step)
+	}
+
+	if err == nil {
+		return nil
+	}
+	result.Err = err
+	if exitCoder, ok := err.(ExitCoder); ok {
+		result.ExitCode = exitCoder.ExitStatus()
+	}
+	return errors.Wrapf(err, "command %s failed", step.Command.Id)
+}
+
+// syncWriter serializes the writes of the concurrent steps
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// lineWriter writes the output of a step line by line, prefixed and colored in the DisplayLog style
+type lineWriter struct {
+	out    *syncWriter
+	prefix string
+	color  *color.Color
+	buf    []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := w.writeLine(w.buf[:i]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes the last line if it doesn't end with a new line
+func (w *lineWriter) Flush() {
+	if len(w.buf) > 0 {
+		_ = w.writeLine(w.buf)
+		w.buf = nil
+	}
+}
+
+func (w *lineWriter) writeLine(line []byte) error {
+	w.out.mu.Lock()
+	defer w.out.mu.Unlock()
+	_, err := fmt.Fprintln(w.out.w, w.color.Sprint(w.prefix+string(line)))
+	return err
+}
@@ -0,0 +1,229 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/commands"
+)
+
+func getExecPlan(id, component string) commands.CommandPlan {
+	return commands.CommandPlan{
+		CommandID: id,
+		Step: &commands.CommandStep{
+			Command: v1.Command{
+				Id: id,
+				CommandUnion: v1.CommandUnion{
+					Exec: &v1.ExecCommand{
+						CommandLine: "echo " + id,
+						Component:   component,
+					},
+				},
+			},
+			Component: v1.Component{Name: component},
+		},
+	}
+}
+
+func getApplyPlan(id, component string) commands.CommandPlan {
+	return commands.CommandPlan{
+		CommandID: id,
+		Step: &commands.CommandStep{
+			Command: v1.Command{
+				Id: id,
+				CommandUnion: v1.CommandUnion{
+					Apply: &v1.ApplyCommand{
+						Component: component,
+					},
+				},
+			},
+			Component: v1.Component{Name: component},
+		},
+	}
+}
+
+type fakeApplier struct {
+	applied []string
+}
+
+func (a *fakeApplier) Apply(ctx context.Context, step commands.CommandStep) error {
+	a.applied = append(a.applied, step.Component.Name)
+	return nil
+}
+
+func TestRunnerRun(t *testing.T) {
+
+	plan := commands.CommandPlan{
+		CommandID: "all",
+		Commands: []commands.CommandPlan{
+			getExecPlan("build", "runtime"),
+			{
+				CommandID: "checks",
+				Parallel:  true,
+				Commands: []commands.CommandPlan{
+					getExecPlan("unit", "runtime"),
+					getExecPlan("lint", "tools"),
+				},
+			},
+			getApplyPlan("deploy", "manifests"),
+		},
+	}
+
+	tests := []struct {
+		name          string
+		results       map[string]FakeResult
+		wantExecuted  []string
+		wantApplied   []string
+		wantRun       []bool
+		wantExitCodes []int
+		wantOutput    []string
+		wantErr       bool
+	}{
+		{
+			name: "Case 1: All the steps succeed",
+			results: map[string]FakeResult{
+				"build": {Stdout: "compiling\ndone\n"},
+				"lint":  {Stderr: "warning"},
+			},
+			wantExecuted:  []string{"build", "lint", "unit"},
+			wantApplied:   []string{"manifests"},
+			wantRun:       []bool{true, true, true, true},
+			wantExitCodes: []int{0, 0, 0, 0},
+			wantOutput:    []string{"[build] compiling", "[build] done", "[lint] warning"},
+		},
+		{
+			name: "Case 2: The first step fails",
+			results: map[string]FakeResult{
+				"build": {Err: errors.New("connection lost")},
+			},
+			wantExecuted:  []string{"build"},
+			wantRun:       []bool{true, false, false, false},
+			wantExitCodes: []int{0, 0, 0, 0},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &FakeExecutor{Results: tt.results}
+			applier := &fakeApplier{}
+			out := &bytes.Buffer{}
+			runner := Runner{
+				Executor: executor,
+				Applier:  applier,
+				Out:      out,
+			}
+
+			results, err := runner.Run(context.Background(), plan)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestRunnerRun error: %v, wantErr %v", err, tt.wantErr)
+			}
+
+			executed := executor.Executed()
+			sort.Strings(executed[1:])
+			if !reflect.DeepEqual(executed, tt.wantExecuted) {
+				t.Errorf("TestRunnerRun error: executed mismatch - got: %v, wanted: %v", executed, tt.wantExecuted)
+			}
+			if !reflect.DeepEqual(applier.applied, tt.wantApplied) {
+				t.Errorf("TestRunnerRun error: applied mismatch - got: %v, wanted: %v", applier.applied, tt.wantApplied)
+			}
+
+			var run []bool
+			var exitCodes []int
+			for _, result := range results {
+				run = append(run, result.Ran)
+				exitCodes = append(exitCodes, result.ExitCode)
+			}
+			if !reflect.DeepEqual(run, tt.wantRun) {
+				t.Errorf("TestRunnerRun error: run mismatch - got: %v, wanted: %v", run, tt.wantRun)
+			}
+			if !reflect.DeepEqual(exitCodes, tt.wantExitCodes) {
+				t.Errorf("TestRunnerRun error: exit codes mismatch - got: %v, wanted: %v", exitCodes, tt.wantExitCodes)
+			}
+			if results[1].CommandID != "unit" || results[1].ComponentName != "runtime" {
+				t.Errorf("TestRunnerRun error: results are not in the plan order - got: %v", results)
+			}
+
+			var output []string
+			if out.Len() > 0 {
+				output = strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+			}
+			sort.Strings(output)
+			wantOutput := append([]string(nil), tt.wantOutput...)
+			sort.Strings(wantOutput)
+			if !reflect.DeepEqual(output, wantOutput) {
+				t.Errorf("TestRunnerRun error: output mismatch - got: %v, wanted: %v", output, wantOutput)
+			}
+		})
+	}
+}
+
+// blockingExecutor fails the failing command and blocks the other commands until they are cancelled
+type blockingExecutor struct {
+	failing string
+}
+
+func (e blockingExecutor) Execute(ctx context.Context, step commands.CommandStep, stdout, stderr io.Writer) error {
+	if step.Command.Id == e.failing {
+		return &ExitError{Code: 2}
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(10 * time.Second):
+		return errors.New("the command was not cancelled")
+	}
+}
+
+func TestRunnerRunParallelFailure(t *testing.T) {
+	plan := commands.CommandPlan{
+		CommandID: "checks",
+		Parallel:  true,
+		Commands: []commands.CommandPlan{
+			getExecPlan("unit", "runtime"),
+			getExecPlan("lint", "tools"),
+		},
+	}
+
+	runner := Runner{Executor: blockingExecutor{failing: "unit"}, Out: &bytes.Buffer{}}
+	results, err := runner.Run(context.Background(), plan)
+	if err == nil || strings.Contains(err.Error(), "lint") {
+		t.Errorf("TestRunnerRunParallelFailure error: expected only the failure of unit, got %v", err)
+	}
+	if results[0].ExitCode != 2 {
+		t.Errorf("TestRunnerRunParallelFailure error: exit code mismatch - got: %d, wanted: 2", results[0].ExitCode)
+	}
+	if results[1].Ran && results[1].Err != context.Canceled {
+		t.Errorf("TestRunnerRunParallelFailure error: lint should be cancelled, got %v", results[1].Err)
+	}
+}
+
+func TestRunnerRunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	executor := &FakeExecutor{}
+	runner := Runner{Executor: executor, Out: &bytes.Buffer{}}
+	results, err := runner.Run(ctx, getExecPlan("build", "runtime"))
+	if err != context.Canceled {
+		t.Errorf("TestRunnerRunCancelled error: got %v, wanted %v", err, context.Canceled)
+	}
+	if len(executor.Executed()) != 0 || results[0].Ran {
+		t.Errorf("TestRunnerRunCancelled error: the step should not run")
+	}
+}
+
+func TestRunnerRunWithoutApplier(t *testing.T) {
+	runner := Runner{Executor: &FakeExecutor{}, Out: &bytes.Buffer{}}
+	results, err := runner.Run(context.Background(), getApplyPlan("deploy", "manifests"))
+	if err == nil || results[0].Err == nil {
+		t.Errorf("TestRunnerRunWithoutApplier error: expected an error")
+	}
+}
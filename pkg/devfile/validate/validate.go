@@ -1,14 +1,152 @@
 package validate
 
 import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser/data"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 	"k8s.io/klog"
 )
 
 // ValidateDevfileData validates whether sections of devfile are compatible
-func ValidateDevfileData(data interface{}) error {
+func ValidateDevfileData(devfileData interface{}) error {
+	d, ok := devfileData.(data.DevfileData)
+	if !ok {
+		klog.V(4).Infof("No validation present for the devfile data of type %T. Skipped for the moment.", devfileData)
+		return nil
+	}
+
+	components, err := d.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+	commands, err := d.GetCommands(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+	events, err := d.GetEvents(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	errs = append(errs, validateComponents(components)...)
+	errs = append(errs, validateCommands(commands, components)...)
+	errs = append(errs, validateEvents(events, commands)...)
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid devfile data. errors :\n- %s", strings.Join(errs, "\n- "))
+	}
 
-	// Skipped
-	klog.V(4).Info("No validation present. Skipped for the moment.")
+	klog.V(4).Info("validated devfile data")
 	return nil
+}
+
+// validateComponents checks that the component names are unique for each kind of component
+// and that the volume mounts of the containers refer to volume components
+func validateComponents(components []v1.Component) []string {
+	var errs []string
+	names := make(map[string]bool)
+	volumes := make(map[string]bool)
+	for _, component := range components {
+		componentType, err := common.GetComponentType(component)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		key := string(componentType) + "/" + component.Name
+		if names[key] {
+			errs = append(errs, fmt.Sprintf("duplicate %s component %s", componentType, component.Name))
+		}
+		names[key] = true
+		if component.Volume != nil {
+			volumes[component.Name] = true
+		}
+	}
+
+	for _, component := range components {
+		if component.Container == nil {
+			continue
+		}
+		for _, volumeMount := range component.Container.VolumeMounts {
+			if !volumes[volumeMount.Name] {
+				errs = append(errs, fmt.Sprintf("volume %s mounted by the container %s is not found", volumeMount.Name, component.Name))
+			}
+		}
+	}
+	return errs
+}
+
+// validateCommands checks that the command ids are unique, that the commands refer to existing components and commands,
+// and that the command groups have at most one default command
+func validateCommands(commands []v1.Command, components []v1.Component) []string {
+	var errs []string
+	componentNames := make(map[string]bool)
+	for _, component := range components {
+		componentNames[component.Name] = true
+	}
+	commandIDs := make(map[string]bool)
+	for _, command := range commands {
+		id := strings.ToLower(command.Id)
+		if commandIDs[id] {
+			errs = append(errs, fmt.Sprintf("duplicate command %s", command.Id))
+		}
+		commandIDs[id] = true
+	}
+
+	defaultCommands := make(map[v1.CommandGroupKind][]string)
+	for _, command := range commands {
+		var component string
+		switch {
+		case command.Exec != nil:
+			component = command.Exec.Component
+		case command.Apply != nil:
+			component = command.Apply.Component
+		case command.Composite != nil:
+			for _, subCommand := range command.Composite.Commands {
+				if !commandIDs[strings.ToLower(subCommand)] {
+					errs = append(errs, fmt.Sprintf("command %s of the composite command %s is not found", subCommand, command.Id))
+				}
+			}
+		}
+		if component != "" && !componentNames[component] {
+			errs = append(errs, fmt.Sprintf("component %s of the command %s is not found", component, command.Id))
+		}
+
+		if group := common.GetGroup(command); group != nil && group.IsDefault {
+			defaultCommands[group.Kind] = append(defaultCommands[group.Kind], command.Id)
+		}
+	}
+	for _, groupKind := range []v1.CommandGroupKind{v1.BuildCommandGroupKind, v1.RunCommandGroupKind, v1.TestCommandGroupKind, v1.DebugCommandGroupKind} {
+		if len(defaultCommands[groupKind]) > 1 {
+			errs = append(errs, (&common.MultipleDefaultCommandsError{GroupKind: groupKind, Commands: defaultCommands[groupKind]}).Error())
+		}
+	}
+	return errs
+}
 
+// validateEvents checks that the events refer to existing commands
+func validateEvents(events v1.Events, commands []v1.Command) []string {
+	var errs []string
+	commandIDs := make(map[string]bool)
+	for _, command := range commands {
+		commandIDs[strings.ToLower(command.Id)] = true
+	}
+	for _, event := range []struct {
+		name       string
+		commandIDs []string
+	}{
+		{name: "preStart", commandIDs: events.PreStart},
+		{name: "postStart", commandIDs: events.PostStart},
+		{name: "preStop", commandIDs: events.PreStop},
+		{name: "postStop", commandIDs: events.PostStop},
+	} {
+		for _, id := range event.commandIDs {
+			if !commandIDs[strings.ToLower(id)] {
+				errs = append(errs, fmt.Sprintf("command %s of the %s event is not found", id, event.name))
+			}
+		}
+	}
+	return errs
 }
@@ -0,0 +1,111 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	v2 "github.com/devfile/library/pkg/devfile/parser/data/v2"
+	"github.com/devfile/library/pkg/testingutil"
+)
+
+func TestValidateDevfileData(t *testing.T) {
+
+	runtime := testingutil.GetFakeContainerComponent("runtime")
+	runtime.Container.VolumeMounts = []v1.VolumeMount{testingutil.GetFakeVolumeMount("data", "/data")}
+	data := testingutil.GetFakeVolumeComponent("data", "1Gi")
+	getExecCommand := func(id, component string, group *v1.CommandGroup) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					LabeledCommand: v1.LabeledCommand{
+						BaseCommand: v1.BaseCommand{
+							Group: group,
+						},
+					},
+					Component: component,
+				},
+			},
+		}
+	}
+	buildGroup := &v1.CommandGroup{Kind: v1.BuildCommandGroupKind, IsDefault: true}
+
+	tests := []struct {
+		name       string
+		components []v1.Component
+		commands   []v1.Command
+		events     v1.WorkspaceEvents
+		wantErrs   []string
+	}{
+		{
+			name:       "Case 1: Valid devfile data",
+			components: []v1.Component{runtime, data},
+			commands: []v1.Command{
+				getExecCommand("build", "runtime", buildGroup),
+				{
+					Id: "buildandrun",
+					CommandUnion: v1.CommandUnion{
+						Composite: &v1.CompositeCommand{Commands: []string{"Build"}},
+					},
+				},
+			},
+			events: v1.WorkspaceEvents{PostStart: []string{"build"}},
+		},
+		{
+			name:       "Case 2: Invalid references",
+			components: []v1.Component{runtime},
+			commands: []v1.Command{
+				getExecCommand("build", "tools", nil),
+				{
+					Id: "buildandrun",
+					CommandUnion: v1.CommandUnion{
+						Composite: &v1.CompositeCommand{Commands: []string{"build", "run"}},
+					},
+				},
+			},
+			events: v1.WorkspaceEvents{PreStop: []string{"stop"}},
+			wantErrs: []string{
+				"volume data mounted by the container runtime is not found",
+				"component tools of the command build is not found",
+				"command run of the composite command buildandrun is not found",
+				"command stop of the preStop event is not found",
+			},
+		},
+		{
+			name:       "Case 3: Duplicate components, commands and default commands",
+			components: []v1.Component{runtime, data, runtime},
+			commands: []v1.Command{
+				getExecCommand("build", "runtime", buildGroup),
+				getExecCommand("Build", "runtime", buildGroup),
+			},
+			wantErrs: []string{
+				"duplicate Container component runtime",
+				"duplicate command build",
+				"the build commands build, build are all marked as default",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{}
+			d.Components = tt.components
+			d.Commands = tt.commands
+			d.Events = &v1.Events{WorkspaceEvents: tt.events}
+
+			err := ValidateDevfileData(d)
+			if len(tt.wantErrs) == 0 && err != nil {
+				t.Errorf("TestValidateDevfileData unexpected error: %v", err)
+			}
+			if len(tt.wantErrs) > 0 && err == nil {
+				t.Errorf("TestValidateDevfileData expected an error")
+				return
+			}
+			for _, wantErr := range tt.wantErrs {
+				if !strings.Contains(err.Error(), wantErr) {
+					t.Errorf("TestValidateDevfileData error %q does not contain %q", err.Error(), wantErr)
+				}
+			}
+		})
+	}
+}
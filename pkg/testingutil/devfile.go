@@ -5,12 +5,14 @@ import (
 	"strings"
 
 	v1 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/attributes"
 	devfilepkg "github.com/devfile/api/pkg/devfile"
 	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 )
 
 // TestDevfileData is a convenience data type used to mock up a devfile configuration
 type TestDevfileData struct {
+	Metadata          devfilepkg.DevfileMetadata
 	Components        []v1.Component
 	ExecCommands      []v1.ExecCommand
 	CompositeCommands []v1.CompositeCommand
@@ -20,7 +22,7 @@ type TestDevfileData struct {
 
 // GetMetadata is a mock function to get metadata from devfile
 func (d TestDevfileData) GetMetadata() devfilepkg.DevfileMetadata {
-	return devfilepkg.DevfileMetadata{}
+	return d.Metadata
 }
 
 // SetMetadata sets metadata for the test devfile
@@ -41,8 +43,16 @@ func (d TestDevfileData) GetParent() *v1.Parent {
 func (d TestDevfileData) SetParent(parent *v1.Parent) {}
 
 // GetEvents is a mock function to get events from devfile
-func (d TestDevfileData) GetEvents() v1.Events {
-	return d.Events
+func (d TestDevfileData) GetEvents(options common.DevfileOptions) (v1.Events, error) {
+	if !options.IsFiltered() {
+		return d.Events, nil
+	}
+
+	commands, err := d.GetCommands(options)
+	if err != nil {
+		return v1.Events{}, err
+	}
+	return common.FilterEvents(d.Events, commands), nil
 }
 
 // AddEvents is a mock function to add events to the test devfile
@@ -53,13 +63,13 @@ func (d TestDevfileData) UpdateEvents(postStart, postStop, preStart, preStop []s
 
 // GetComponents is a mock function to get the components from a devfile
 func (d TestDevfileData) GetComponents(options common.DevfileOptions) ([]v1.Component, error) {
-	if len(options.Filter) == 0 {
+	if !options.IsFiltered() {
 		return d.Components, nil
 	}
 
 	var components []v1.Component
 	for _, comp := range d.Components {
-		filterIn, err := common.FilterDevfileObject(comp.Attributes, options)
+		filterIn, err := common.FilterDevfileComponent(comp, options)
 		if err != nil {
 			return nil, err
 		}
@@ -76,7 +86,20 @@ func (d TestDevfileData) GetComponents(options common.DevfileOptions) ([]v1.Comp
 func (d TestDevfileData) AddComponents(components []v1.Component) error { return nil }
 
 // UpdateComponent is a mock function to update the component of the test devfile
-func (d TestDevfileData) UpdateComponent(component v1.Component) {}
+func (d TestDevfileData) UpdateComponent(component v1.Component) error { return nil }
+
+// UpsertComponent is a mock function to update or add a component in the test devfile
+func (d TestDevfileData) UpsertComponent(component v1.Component) {}
+
+// DeleteComponent is a mock function to delete a component from the test devfile
+func (d TestDevfileData) DeleteComponent(name string, componentType v1.ComponentType) error {
+	return nil
+}
+
+// RenameComponent is a mock function to rename a component of the test devfile
+func (d TestDevfileData) RenameComponent(oldName, newName string, componentType v1.ComponentType) error {
+	return nil
+}
 
 // GetProjects is a mock function to get the projects from a test devfile
 func (d TestDevfileData) GetProjects(options common.DevfileOptions) ([]v1.Project, error) {
@@ -111,15 +134,36 @@ func (d TestDevfileData) GetProjects(options common.DevfileOptions) ([]v1.Projec
 			},
 		},
 	}
-	return []v1.Project{project1, project2}, nil
 
+	var projects []v1.Project
+	for _, project := range []v1.Project{project1, project2} {
+		filterIn, err := common.FilterDevfileProject(project, options)
+		if err != nil {
+			return nil, err
+		}
+
+		if filterIn {
+			projects = append(projects, project)
+		}
+	}
+
+	return projects, nil
 }
 
 // AddProjects is a mock function to add projects to the test devfile
 func (d TestDevfileData) AddProjects(projects []v1.Project) error { return nil }
 
 // UpdateProject is a mock function to update a project for the test devfile
-func (d TestDevfileData) UpdateProject(project v1.Project) {}
+func (d TestDevfileData) UpdateProject(project v1.Project) error { return nil }
+
+// UpsertProject is a mock function to update or add a project in the test devfile
+func (d TestDevfileData) UpsertProject(project v1.Project) {}
+
+// DeleteProject is a mock function to delete a project from the test devfile
+func (d TestDevfileData) DeleteProject(name string) error { return nil }
+
+// RenameProject is a mock function to rename a project of the test devfile
+func (d TestDevfileData) RenameProject(oldName, newName string) error { return nil }
 
 // GetStarterProjects is a mock function to get the starter projects from a test devfile
 func (d TestDevfileData) GetStarterProjects(options common.DevfileOptions) ([]v1.StarterProject, error) {
@@ -132,7 +176,16 @@ func (d TestDevfileData) AddStarterProjects(projects []v1.StarterProject) error
 }
 
 // UpdateStarterProject is a mock func to update the starter project for a test devfile
-func (d TestDevfileData) UpdateStarterProject(project v1.StarterProject) {}
+func (d TestDevfileData) UpdateStarterProject(project v1.StarterProject) error { return nil }
+
+// UpsertStarterProject is a mock func to update or add a starter project in the test devfile
+func (d TestDevfileData) UpsertStarterProject(project v1.StarterProject) {}
+
+// DeleteStarterProject is a mock func to delete a starter project from the test devfile
+func (d TestDevfileData) DeleteStarterProject(name string) error { return nil }
+
+// RenameStarterProject is a mock func to rename a starter project of the test devfile
+func (d TestDevfileData) RenameStarterProject(oldName, newName string) error { return nil }
 
 // GetCommands is a mock function to get the commands from a devfile
 func (d TestDevfileData) GetCommands(options common.DevfileOptions) ([]v1.Command, error) {
@@ -140,15 +193,40 @@ func (d TestDevfileData) GetCommands(options common.DevfileOptions) ([]v1.Comman
 	var commands []v1.Command
 
 	for _, command := range d.Commands {
-		// we convert devfile command id to lowercase so that we can handle
-		// cases efficiently without being error prone
-		command.Id = strings.ToLower(command.Id)
-		commands = append(commands, command)
+		filterIn, err := common.FilterDevfileCommand(command, options)
+		if err != nil {
+			return nil, err
+		}
+
+		if filterIn {
+			// we convert devfile command id to lowercase so that we can handle
+			// cases efficiently without being error prone
+			command.Id = strings.ToLower(command.Id)
+			commands = append(commands, command)
+		}
 	}
 
 	return commands, nil
 }
 
+// GetCommandsByGroup is a mock function to get the commands of a group from the test devfile
+func (d TestDevfileData) GetCommandsByGroup(groupKind v1.CommandGroupKind, options common.DevfileOptions) ([]v1.Command, error) {
+	commands, err := d.GetCommands(options)
+	if err != nil {
+		return nil, err
+	}
+	return common.GetCommandsByGroup(commands, groupKind), nil
+}
+
+// GetDefaultCommand is a mock function to get the default command of a group from the test devfile
+func (d TestDevfileData) GetDefaultCommand(groupKind v1.CommandGroupKind, options common.DevfileOptions) (v1.Command, error) {
+	commands, err := d.GetCommands(options)
+	if err != nil {
+		return v1.Command{}, err
+	}
+	return common.GetDefaultCommand(commands, groupKind)
+}
+
 // AddCommands is a mock func that adds commands to the test devfile
 func (d *TestDevfileData) AddCommands(commands ...v1.Command) error {
 	devfileCommands, err := d.GetCommands(common.DevfileOptions{})
@@ -170,7 +248,16 @@ func (d *TestDevfileData) AddCommands(commands ...v1.Command) error {
 }
 
 // UpdateCommand is a mock func to update the command in a test devfile
-func (d TestDevfileData) UpdateCommand(command v1.Command) {}
+func (d TestDevfileData) UpdateCommand(command v1.Command) error { return nil }
+
+// UpsertCommand is a mock func to update or add a command in the test devfile
+func (d TestDevfileData) UpsertCommand(command v1.Command) {}
+
+// DeleteCommand is a mock func to delete a command from the test devfile
+func (d TestDevfileData) DeleteCommand(id string) error { return nil }
+
+// RenameCommand is a mock func to rename a command of the test devfile
+func (d TestDevfileData) RenameCommand(oldID, newID string) error { return nil }
 
 // AddVolume is a mock func that adds volume to the test devfile
 func (d TestDevfileData) AddVolume(volumeComponent v1.Component, path string) error {
@@ -181,7 +268,7 @@ func (d TestDevfileData) AddVolume(volumeComponent v1.Component, path string) er
 func (d TestDevfileData) DeleteVolume(name string) error { return nil }
 
 // GetVolumeMountPath is a mock func that gets the volume mount path of a container
-func (d TestDevfileData) GetVolumeMountPath(name string) (string, error) {
+func (d TestDevfileData) GetVolumeMountPath(name string, options common.DevfileOptions) (string, error) {
 	return "", nil
 }
 
@@ -220,6 +307,17 @@ func (d TestDevfileData) Validate() error {
 	return nil
 }
 
+// GetFakeDevfileDataWithMetadataAttributes returns a fake devfile data for testing, with the metadata attributes
+func GetFakeDevfileDataWithMetadataAttributes(metadataAttributes map[string]interface{}) *TestDevfileData {
+	var err error
+	return &TestDevfileData{
+		Metadata: devfilepkg.DevfileMetadata{
+			Name:       "nodejs",
+			Attributes: attributes.Attributes{}.FromMap(metadataAttributes, &err),
+		},
+	}
+}
+
 // GetFakeContainerComponent returns a fake container component for testing.
 func GetFakeContainerComponent(name string) v1.Component {
 	image := "docker.io/maven:latest"
@@ -82,6 +82,11 @@ func (DefaultFs) Chtimes(name string, atime time.Time, mtime time.Time) error {
 	return os.Chtimes(name, atime, mtime)
 }
 
+// Chmod via os.Chmod
+func (DefaultFs) Chmod(name string, mode os.FileMode) error {
+	return os.Chmod(name, mode)
+}
+
 // RemoveAll via os.RemoveAll
 func (DefaultFs) RemoveAll(path string) error {
 	return os.RemoveAll(path)
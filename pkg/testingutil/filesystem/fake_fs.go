@@ -24,6 +24,7 @@ package filesystem
 import (
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/spf13/afero"
@@ -32,6 +33,9 @@ import (
 // fakeFs is implemented in terms of afero
 type fakeFs struct {
 	a afero.Afero
+
+	// mu makes the exclusive creation of files atomic
+	mu sync.Mutex
 }
 
 // NewFakeFs returns a fake Filesystem that exists in-memory, useful for unit tests
@@ -62,8 +66,15 @@ func (fs *fakeFs) Open(name string) (File, error) {
 	return &fakeFile{file}, nil
 }
 
-// OpenFile via afero.Fs.OpenFile
+// OpenFile via afero.Fs.OpenFile, afero ignores os.O_EXCL so it is checked here
 func (fs *fakeFs) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	if flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0 {
+		fs.mu.Lock()
+		defer fs.mu.Unlock()
+		if _, err := fs.a.Fs.Stat(name); err == nil {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrExist}
+		}
+	}
 	file, err := fs.a.Fs.OpenFile(name, flag, perm)
 	if err != nil {
 		return nil, err
@@ -86,6 +97,11 @@ func (fs *fakeFs) Chtimes(name string, atime time.Time, mtime time.Time) error {
 	return fs.a.Fs.Chtimes(name, atime, mtime)
 }
 
+// Chmod via afero.Fs.Chmod
+func (fs *fakeFs) Chmod(name string, mode os.FileMode) error {
+	return fs.a.Fs.Chmod(name, mode)
+}
+
 // ReadFile via afero.ReadFile
 func (fs *fakeFs) ReadFile(filename string) ([]byte, error) {
 	return fs.a.ReadFile(filename)
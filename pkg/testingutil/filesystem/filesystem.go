@@ -37,6 +37,7 @@ type Filesystem interface {
 	Rename(oldpath, newpath string) error
 	MkdirAll(path string, perm os.FileMode) error
 	Chtimes(name string, atime time.Time, mtime time.Time) error
+	Chmod(name string, mode os.FileMode) error
 	RemoveAll(path string) error
 	Remove(name string) error
 
@@ -0,0 +1,470 @@
+package util
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+)
+
+const (
+	// DefaultMaxExtractSize is the default maximum total size of the files extracted from an archive, 1GB
+	DefaultMaxExtractSize int64 = 1024 * 1024 * 1024
+	// DefaultMaxExtractEntries is the default maximum number of entries of an extracted archive
+	DefaultMaxExtractEntries = 100000
+	// maxSymlinkTargetSize is the maximum size of the target of a symbolic link stored in a zip archive
+	maxSymlinkTargetSize = 4096
+	// archiveHeaderSize is the number of bytes read to detect the format of an archive
+	archiveHeaderSize = 4
+)
+
+// ArchiveFormat is the format of an archive
+type ArchiveFormat string
+
+const (
+	// ZipArchive is the zip archive format
+	ZipArchive ArchiveFormat = "zip"
+	// TarGzArchive is the gzip compressed tar archive format
+	TarGzArchive ArchiveFormat = "tar.gz"
+)
+
+var (
+	zipMagic  = [][]byte{[]byte("PK\x03\x04"), []byte("PK\x05\x06")}
+	gzipMagic = []byte("\x1f\x8b")
+)
+
+// ExtractOptions are the options of Extract
+type ExtractOptions struct {
+	// StripComponents is the number of leading path components removed from the names of the entries,
+	// the entries with fewer path components are skipped
+	StripComponents int
+	// StripRootFolder removes the first path component of the entries if all the entries are in a single root folder,
+	// as the archives of the git providers. The entries of an archive without a root folder are kept
+	StripRootFolder bool
+	// PathToExtract is the path of the directory of the archive, after the stripped components, whose content is
+	// extracted in the destination. If it is a pattern, the matching entries are extracted with their path
+	PathToExtract string
+	// MaxSize is the maximum total size of the extracted files, DefaultMaxExtractSize if 0
+	MaxSize int64
+	// MaxEntries is the maximum number of entries of the archive, DefaultMaxExtractEntries if 0
+	MaxEntries int
+}
+
+// DetectArchiveFormat returns the format of an archive from its first bytes
+func DetectArchiveFormat(header []byte) (ArchiveFormat, error) {
+	for _, magic := range zipMagic {
+		if bytes.HasPrefix(header, magic) {
+			return ZipArchive, nil
+		}
+	}
+	if bytes.HasPrefix(header, gzipMagic) {
+		return TarGzArchive, nil
+	}
+	return "", errors.New("unsupported archive format, only zip and tar.gz archives are supported")
+}
+
+// Extract extracts the zip or tar.gz archive at the src path in the dest directory, the format of the archive is
+// detected from its content. The files are streamed to the disk and the extraction errors out if the archive exceeds
+// the size or the entry count limits. The extracted files are readable, and executable if executable in the archive.
+// Entries outside of the destination and symbolic links pointing outside of the destination are rejected.
+// Returns the paths of the extracted files and directories
+func Extract(src, dest string, opts ExtractOptions) ([]string, error) {
+	file, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() // #nosec G307
+
+	header := make([]byte, archiveHeaderSize)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	format, err := DetectArchiveFormat(header[:n])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to extract %s", src)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	x, err := newExtractor(dest, opts)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case ZipArchive:
+		info, err := file.Stat()
+		if err != nil {
+			return nil, err
+		}
+		err = x.extractZip(file, info.Size())
+		return x.filenames, err
+	default:
+		if opts.StripRootFolder {
+			if err := x.findTarRootFolder(file); err != nil {
+				return nil, errors.Wrapf(err, "failed to extract %s", src)
+			}
+		}
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to extract %s", src)
+		}
+		defer gz.Close()
+		err = x.extractTar(gz)
+		return x.filenames, err
+	}
+}
+
+// extractor extracts the entries of an archive in a destination directory
+type extractor struct {
+	dest      string
+	opts      ExtractOptions
+	size      int64
+	entries   int
+	matched   []string
+	filenames []string
+}
+
+func newExtractor(dest string, opts ExtractOptions) (*extractor, error) {
+	if opts.MaxSize == 0 {
+		opts.MaxSize = DefaultMaxExtractSize
+	}
+	if opts.MaxEntries == 0 {
+		opts.MaxEntries = DefaultMaxExtractEntries
+	}
+	opts.PathToExtract = strings.Trim(path.Clean("/"+filepath.ToSlash(opts.PathToExtract)), "/")
+	if _, err := path.Match(opts.PathToExtract, ""); err != nil {
+		return nil, errors.Wrapf(err, "invalid path to extract %s", opts.PathToExtract)
+	}
+	dest, err := filepath.Abs(dest)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dest, os.ModePerm); err != nil {
+		return nil, err
+	}
+	return &extractor{dest: dest, opts: opts}, nil
+}
+
+func (x *extractor) extractZip(r io.ReaderAt, size int64) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+	if len(zr.File) > x.opts.MaxEntries {
+		return errors.Errorf("the archive has more than %d entries", x.opts.MaxEntries)
+	}
+	if x.opts.StripRootFolder {
+		var names []string
+		for _, f := range zr.File {
+			names = append(names, entryName(f.Name, f.Mode().IsDir()))
+		}
+		x.stripRootFolder(names)
+	}
+	for _, f := range zr.File {
+		mode := f.Mode()
+		switch {
+		case mode.IsDir():
+			err = x.extractDir(f.Name)
+		case mode&os.ModeSymlink != 0:
+			err = x.extractZipSymlink(f)
+		case mode.IsRegular():
+			err = x.extractZipFile(f)
+		default:
+			klog.V(4).Infof("skipping the entry %s with mode %s of the archive", f.Name, mode)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *extractor) extractZipFile(f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return x.extractFile(f.Name, rc, f.Mode())
+}
+
+func (x *extractor) extractZipSymlink(f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	target, err := ioutil.ReadAll(io.LimitReader(rc, maxSymlinkTargetSize+1))
+	if err != nil {
+		return err
+	}
+	if len(target) > maxSymlinkTargetSize {
+		return errors.Errorf("%s: target of the symbolic link is too long", f.Name)
+	}
+	return x.extractSymlink(f.Name, string(target))
+}
+
+func (x *extractor) extractTar(r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		x.entries++
+		if x.entries > x.opts.MaxEntries {
+			return errors.Errorf("the archive has more than %d entries", x.opts.MaxEntries)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = x.extractDir(hdr.Name)
+		case tar.TypeSymlink:
+			err = x.extractSymlink(hdr.Name, hdr.Linkname)
+		case tar.TypeReg:
+			err = x.extractFile(hdr.Name, tr, hdr.FileInfo().Mode())
+		default:
+			klog.V(4).Infof("skipping the entry %s of type %c of the archive", hdr.Name, hdr.Typeflag)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// findTarRootFolder reads the entry names of the tar.gz archive to strip its root folder, the file is read
+// again from its start afterwards
+func (x *extractor) findTarRootFolder(file io.ReadSeeker) error {
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	var names []string
+	tr := tar.NewReader(gz)
+	for entries := 1; ; entries++ {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if entries > x.opts.MaxEntries {
+			return errors.Errorf("the archive has more than %d entries", x.opts.MaxEntries)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir, tar.TypeSymlink, tar.TypeReg:
+			names = append(names, entryName(hdr.Name, hdr.Typeflag == tar.TypeDir))
+		}
+	}
+	x.stripRootFolder(names)
+	_, err = file.Seek(0, io.SeekStart)
+	return err
+}
+
+// entryName returns the name of the archive entry, with a trailing slash for a directory
+func entryName(name string, isDir bool) string {
+	name = filepath.ToSlash(name)
+	if isDir && !strings.HasSuffix(name, "/") {
+		name += "/"
+	}
+	return name
+}
+
+// stripRootFolder strips the first path component of the entries, after the stripped components,
+// if the entries with the names are all in a single root folder
+func (x *extractor) stripRootFolder(names []string) {
+	root := ""
+	for _, name := range names {
+		isDir := strings.HasSuffix(name, "/")
+		name = path.Clean(name)
+		if name == "." {
+			continue
+		}
+		parts := strings.Split(name, "/")
+		if len(parts) <= x.opts.StripComponents {
+			continue
+		}
+		parts = parts[x.opts.StripComponents:]
+		if len(parts) == 1 && !isDir {
+			klog.V(4).Infof("the archive has the file %s at its root, its entries are not stripped", name)
+			return
+		}
+		if root != "" && parts[0] != root {
+			klog.V(4).Infof("the archive has several root folders, its entries are not stripped")
+			return
+		}
+		root = parts[0]
+	}
+	if root != "" {
+		x.opts.StripComponents++
+	}
+}
+
+// destPath returns the path in the destination of the entry with the name, and false if the entry is not extracted
+func (x *extractor) destPath(name string) (string, bool, error) {
+	name = filepath.ToSlash(name)
+	if path.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", false, errors.Errorf("%s: illegal file path", name)
+	}
+	name = path.Clean(name)
+	if name == ".." || strings.HasPrefix(name, "../") {
+		return "", false, errors.Errorf("%s: illegal file path", name)
+	}
+	if name == "." {
+		return "", false, nil
+	}
+
+	parts := strings.Split(name, "/")
+	if len(parts) <= x.opts.StripComponents {
+		return "", false, nil
+	}
+	relPath, ok := x.selectEntry(strings.Join(parts[x.opts.StripComponents:], "/"))
+	if !ok {
+		return "", false, nil
+	}
+	fpath := filepath.Join(x.dest, filepath.FromSlash(relPath))
+
+	// entries cannot be written through symbolic links, which could point outside of the destination
+	for dir := filepath.Dir(fpath); dir != x.dest && strings.HasPrefix(dir, x.dest); dir = filepath.Dir(dir) {
+		info, err := os.Lstat(dir)
+		if err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return "", false, errors.Errorf("%s: illegal file path through a symbolic link", name)
+		}
+	}
+	return fpath, true, nil
+}
+
+// selectEntry returns the path of the entry relative to the destination, and false if the entry is not extracted
+func (x *extractor) selectEntry(relPath string) (string, bool) {
+	pathToExtract := x.opts.PathToExtract
+	switch {
+	case pathToExtract == "":
+		return relPath, true
+	case relPath == pathToExtract:
+		return ".", true
+	case strings.HasPrefix(relPath, pathToExtract+"/"):
+		return strings.TrimPrefix(relPath, pathToExtract+"/"), true
+	}
+	for _, matched := range x.matched {
+		if strings.HasPrefix(relPath, matched+"/") {
+			return relPath, true
+		}
+	}
+	if match, _ := path.Match(pathToExtract, relPath); match {
+		x.matched = append(x.matched, relPath)
+		return relPath, true
+	}
+	return "", false
+}
+
+func (x *extractor) extractDir(name string) error {
+	fpath, ok, err := x.destPath(name)
+	if err != nil || !ok {
+		return err
+	}
+	x.filenames = append(x.filenames, fpath)
+	return os.MkdirAll(fpath, os.ModePerm)
+}
+
+func (x *extractor) extractFile(name string, r io.Reader, mode os.FileMode) error {
+	fpath, ok, err := x.destPath(name)
+	if err != nil || !ok {
+		return err
+	}
+	if err := x.prepare(fpath); err != nil {
+		return err
+	}
+	x.filenames = append(x.filenames, fpath)
+
+	perm := os.FileMode(0644)
+	if mode&0111 != 0 {
+		perm = 0755
+	}
+	outFile, err := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close() // #nosec G307
+	if err := outFile.Chmod(perm); err != nil {
+		return err
+	}
+
+	n, err := io.CopyN(outFile, r, x.opts.MaxSize-x.size+1)
+	x.size += n
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if x.size > x.opts.MaxSize {
+		return errors.Errorf("the extracted files of the archive exceed the maximum size of %d bytes", x.opts.MaxSize)
+	}
+	return nil
+}
+
+func (x *extractor) extractSymlink(name, target string) error {
+	fpath, ok, err := x.destPath(name)
+	if err != nil || !ok {
+		return err
+	}
+
+	// the target may only go up to the destination before going down, the ".." components after a symbolic
+	// link would be resolved from the target of the link
+	target = filepath.ToSlash(target)
+	if target == "" || path.IsAbs(target) || filepath.VolumeName(target) != "" {
+		return errors.Errorf("%s: illegal symbolic link target %s", name, target)
+	}
+	relDir, err := filepath.Rel(x.dest, filepath.Dir(fpath))
+	if err != nil {
+		return err
+	}
+	depth := len(strings.Split(filepath.ToSlash(relDir), "/"))
+	if relDir == "." {
+		depth = 0
+	}
+	up, down := 0, false
+	for _, part := range strings.Split(target, "/") {
+		switch {
+		case part == "..":
+			up++
+			if down || up > depth {
+				return errors.Errorf("%s: illegal symbolic link target %s outside of the destination", name, target)
+			}
+		case part != "." && part != "":
+			down = true
+		}
+	}
+
+	if err := x.prepare(fpath); err != nil {
+		return err
+	}
+	x.filenames = append(x.filenames, fpath)
+	return os.Symlink(filepath.FromSlash(target), fpath)
+}
+
+// prepare creates the parent directory of the file at the path, and removes the symbolic link at the path
+// so that it is not followed
+func (x *extractor) prepare(fpath string) error {
+	if err := os.MkdirAll(filepath.Dir(fpath), os.ModePerm); err != nil {
+		return err
+	}
+	if info, err := os.Lstat(fpath); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		return os.Remove(fpath)
+	}
+	return nil
+}
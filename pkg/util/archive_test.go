@@ -0,0 +1,300 @@
+package util
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+// archiveEntry is an entry of a test archive, a directory if the name ends with a slash, a symbolic link if link is set
+type archiveEntry struct {
+	name    string
+	content string
+	mode    os.FileMode
+	link    string
+}
+
+func TestExtract(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links and file modes are not supported on windows")
+	}
+
+	projectEntries := []archiveEntry{
+		{name: "nodejs-ex-main/"},
+		{name: "nodejs-ex-main/package.json", content: "{}", mode: 0644},
+		{name: "nodejs-ex-main/run.sh", content: "npm start", mode: 0755},
+		{name: "nodejs-ex-main/src/server.js", content: "listen(3000)", mode: 0644},
+		{name: "nodejs-ex-main/src/index.js", link: "server.js"},
+	}
+
+	tests := []struct {
+		name      string
+		entries   []archiveEntry
+		opts      ExtractOptions
+		wantFiles map[string]string
+		wantErr   bool
+	}{
+		{
+			name:    "case 1: archive with a root directory stripped",
+			entries: projectEntries,
+			opts:    ExtractOptions{StripComponents: 1},
+			wantFiles: map[string]string{
+				"package.json":  "-rw-r--r--",
+				"run.sh":        "-rwxr-xr-x",
+				"src":           "drwxr-xr-x",
+				"src/index.js":  "Lrwxrwxrwx",
+				"src/server.js": "-rw-r--r--",
+			},
+		},
+		{
+			name:    "case 2: archive without stripped components",
+			entries: projectEntries[1:3],
+			wantFiles: map[string]string{
+				"nodejs-ex-main":              "drwxr-xr-x",
+				"nodejs-ex-main/package.json": "-rw-r--r--",
+				"nodejs-ex-main/run.sh":       "-rwxr-xr-x",
+			},
+		},
+		{
+			name:      "case 3: path to extract",
+			entries:   projectEntries,
+			opts:      ExtractOptions{StripComponents: 1, PathToExtract: "/src/"},
+			wantFiles: map[string]string{"index.js": "Lrwxrwxrwx", "server.js": "-rw-r--r--"},
+		},
+		{
+			name:      "case 4: pattern to extract",
+			entries:   projectEntries,
+			opts:      ExtractOptions{StripComponents: 1, PathToExtract: "*.sh"},
+			wantFiles: map[string]string{"run.sh": "-rwxr-xr-x"},
+		},
+		{
+			name:    "case 5: archive exceeding the maximum size",
+			entries: projectEntries,
+			opts:    ExtractOptions{StripComponents: 1, MaxSize: 20},
+			wantErr: true,
+		},
+		{
+			name:    "case 6: archive exceeding the maximum number of entries",
+			entries: projectEntries,
+			opts:    ExtractOptions{StripComponents: 1, MaxEntries: 4},
+			wantErr: true,
+		},
+		{
+			name:    "case 7: entry outside of the destination",
+			entries: []archiveEntry{{name: "../evil.sh", content: "rm -rf", mode: 0755}},
+			wantErr: true,
+		},
+		{
+			name:    "case 8: symbolic link pointing outside of the destination",
+			entries: []archiveEntry{{name: "project/passwd", link: "../../etc/passwd"}},
+			opts:    ExtractOptions{StripComponents: 1},
+			wantErr: true,
+		},
+		{
+			name:    "case 9: symbolic link escaping through another symbolic link",
+			entries: []archiveEntry{{name: "current", link: "."}, {name: "escape", link: "current/.."}},
+			wantErr: true,
+		},
+		{
+			name:    "case 10: absolute symbolic link",
+			entries: []archiveEntry{{name: "passwd", link: "/etc/passwd"}},
+			wantErr: true,
+		},
+		{
+			name:    "case 11: entry written through a symbolic link",
+			entries: []archiveEntry{{name: "tmp", link: "."}, {name: "tmp/evil.sh", content: "rm -rf", mode: 0755}},
+			wantErr: true,
+		},
+		{
+			name:    "case 12: archive with a single root folder stripped",
+			entries: projectEntries,
+			opts:    ExtractOptions{StripRootFolder: true},
+			wantFiles: map[string]string{
+				"package.json":  "-rw-r--r--",
+				"run.sh":        "-rwxr-xr-x",
+				"src":           "drwxr-xr-x",
+				"src/index.js":  "Lrwxrwxrwx",
+				"src/server.js": "-rw-r--r--",
+			},
+		},
+		{
+			name: "case 13: flat archive without a root folder",
+			entries: []archiveEntry{
+				{name: "package.json", content: "{}", mode: 0644},
+				{name: "src/server.js", content: "listen(3000)", mode: 0644},
+			},
+			opts: ExtractOptions{StripRootFolder: true},
+			wantFiles: map[string]string{
+				"package.json":  "-rw-r--r--",
+				"src":           "drwxr-xr-x",
+				"src/server.js": "-rw-r--r--",
+			},
+		},
+		{
+			name: "case 14: archive with several root folders",
+			entries: []archiveEntry{
+				{name: "src/server.js", content: "listen(3000)", mode: 0644},
+				{name: "test/server_test.js", content: "test()", mode: 0644},
+			},
+			opts: ExtractOptions{StripRootFolder: true},
+			wantFiles: map[string]string{
+				"src":                 "drwxr-xr-x",
+				"src/server.js":       "-rw-r--r--",
+				"test":                "drwxr-xr-x",
+				"test/server_test.js": "-rw-r--r--",
+			},
+		},
+	}
+	for _, tt := range tests {
+		for _, format := range []ArchiveFormat{ZipArchive, TarGzArchive} {
+			t.Run(tt.name+" - "+string(format), func(t *testing.T) {
+				dir, err := ioutil.TempDir("", "extract")
+				if err != nil {
+					t.Fatalf("TestExtract() unexpected error %v", err)
+				}
+				defer os.RemoveAll(dir)
+
+				src := writeArchive(t, dir, format, tt.entries)
+				dest := filepath.Join(dir, "dest")
+				_, err = Extract(src, dest, tt.opts)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("TestExtract() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err != nil {
+					return
+				}
+
+				gotFiles := make(map[string]string)
+				err = filepath.Walk(dest, func(path string, info os.FileInfo, err error) error {
+					if err != nil || path == dest {
+						return err
+					}
+					relPath, err := filepath.Rel(dest, path)
+					gotFiles[filepath.ToSlash(relPath)] = info.Mode().String()
+					return err
+				})
+				if err != nil {
+					t.Fatalf("TestExtract() unexpected error %v", err)
+				}
+				if !reflect.DeepEqual(gotFiles, tt.wantFiles) {
+					t.Errorf("TestExtract() files mismatch - wanted: %v, got: %v", tt.wantFiles, gotFiles)
+				}
+			})
+		}
+	}
+}
+
+func TestDetectArchiveFormat(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		header  string
+		want    ArchiveFormat
+		wantErr bool
+	}{
+		{
+			name:   "case 1: zip archive",
+			header: "PK\x03\x04\x14\x00",
+			want:   ZipArchive,
+		},
+		{
+			name:   "case 2: empty zip archive",
+			header: "PK\x05\x06\x00\x00",
+			want:   ZipArchive,
+		},
+		{
+			name:   "case 3: tar.gz archive",
+			header: "\x1f\x8b\x08\x00",
+			want:   TarGzArchive,
+		},
+		{
+			name:    "case 4: html page",
+			header:  "<!DOCTYPE html>",
+			wantErr: true,
+		},
+		{
+			name:    "case 5: empty file",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectArchiveFormat([]byte(tt.header))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TestDetectArchiveFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TestDetectArchiveFormat() format mismatch - wanted: %s, got: %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// writeArchive writes an archive of the format with the entries in the dir directory
+func writeArchive(t *testing.T, dir string, format ArchiveFormat, entries []archiveEntry) string {
+	path := filepath.Join(dir, "archive")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("writeArchive() unexpected error %v", err)
+	}
+	defer file.Close()
+
+	switch format {
+	case ZipArchive:
+		w := zip.NewWriter(file)
+		for _, entry := range entries {
+			header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate}
+			content := entry.content
+			switch {
+			case entry.link != "":
+				header.SetMode(os.ModeSymlink | 0777)
+				content = entry.link
+			case entry.name[len(entry.name)-1] == '/':
+				header.SetMode(os.ModeDir | 0755)
+			default:
+				header.SetMode(entry.mode)
+			}
+			f, err := w.CreateHeader(header)
+			if err != nil {
+				t.Fatalf("writeArchive() unexpected error %v", err)
+			}
+			if _, err := f.Write([]byte(content)); err != nil {
+				t.Fatalf("writeArchive() unexpected error %v", err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("writeArchive() unexpected error %v", err)
+		}
+	case TarGzArchive:
+		gz := gzip.NewWriter(file)
+		w := tar.NewWriter(gz)
+		for _, entry := range entries {
+			header := &tar.Header{Name: entry.name, Mode: int64(entry.mode), Size: int64(len(entry.content)), Typeflag: tar.TypeReg}
+			switch {
+			case entry.link != "":
+				header.Typeflag, header.Linkname, header.Mode, header.Size = tar.TypeSymlink, entry.link, 0777, 0
+			case entry.name[len(entry.name)-1] == '/':
+				header.Typeflag, header.Mode = tar.TypeDir, 0755
+			}
+			if err := w.WriteHeader(header); err != nil {
+				t.Fatalf("writeArchive() unexpected error %v", err)
+			}
+			if _, err := w.Write([]byte(entry.content)); err != nil {
+				t.Fatalf("writeArchive() unexpected error %v", err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("writeArchive() unexpected error %v", err)
+		}
+		if err := gz.Close(); err != nil {
+			t.Fatalf("writeArchive() unexpected error %v", err)
+		}
+	}
+	return path
+}
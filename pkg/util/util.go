@@ -1,7 +1,6 @@
 package util
 
 import (
-	"archive/zip"
 	"bufio"
 	"bytes"
 	"crypto/rand"
@@ -837,17 +836,13 @@ func ConvertGitSSHRemoteToHTTPS(remote string) string {
 	return remote
 }
 
-// GetAndExtractZip downloads a zip file from a URL with a http prefix or
+// GetAndExtractZip downloads a zip or tar.gz archive from a URL with a http prefix or
 // takes an absolute path prefixed with file:// and extracts it to a destination.
 // pathToUnzip specifies the path within the zip folder to extract
 func GetAndExtractZip(zipURL string, destination string, pathToUnzip string) error {
 	if zipURL == "" {
 		return errors.Errorf("Empty zip url: %s", zipURL)
 	}
-	if !strings.Contains(zipURL, ".zip") {
-		return errors.Errorf("Invalid zip url: %s", zipURL)
-	}
-
 	var pathToZip string
 	if strings.HasPrefix(zipURL, "file://") {
 		pathToZip = strings.TrimPrefix(zipURL, "file:/")
@@ -892,103 +887,15 @@ func GetAndExtractZip(zipURL string, destination string, pathToUnzip string) err
 	return nil
 }
 
-// Unzip will decompress a zip archive, moving specified files and folders
-// within the zip file (parameter 1) to an output directory (parameter 2)
-// Source: https://golangcode.com/unzip-files-in-go/
-// pathToUnzip (parameter 3) is the path within the zip folder to extract
+// Unzip will decompress a zip or tar.gz archive, moving specified files and folders
+// within the archive (parameter 1) to an output directory (parameter 2).
+// The root folder of the archive is removed if all the entries are in a single root folder.
+// pathToUnzip (parameter 3) is the path within the archive folder to extract
 func Unzip(src, dest, pathToUnzip string) ([]string, error) {
-	var filenames []string
-
-	r, err := zip.OpenReader(src)
-	if err != nil {
-		return filenames, err
-	}
-	defer r.Close()
-
-	// change path separator to correct character
-	pathToUnzip = filepath.FromSlash(pathToUnzip)
-
-	// removes first slash of pathToUnzip if present
-	pathToUnzip = strings.TrimPrefix(pathToUnzip, string(os.PathSeparator))
-
-	for _, f := range r.File {
-		// Store filename/path for returning and using later on
-		index := strings.Index(f.Name, "/")
-		filename := filepath.FromSlash(f.Name[index+1:])
-		if filename == "" {
-			continue
-		}
-
-		// if sparseCheckoutDir has a pattern
-		match, err := filepath.Match(pathToUnzip, filename)
-		if err != nil {
-			return filenames, err
-		}
-
-		// destination filepath before trim
-		fpath := filepath.Join(dest, filename)
-
-		// used for pattern matching
-		fpathDir := filepath.Dir(fpath)
-
-		// check for prefix or match
-		if strings.HasPrefix(filename, pathToUnzip) {
-			filename = strings.TrimPrefix(filename, pathToUnzip)
-		} else if !strings.HasPrefix(filename, pathToUnzip) && !match && !sliceContainsString(fpathDir, filenames) {
-			continue
-		}
-		// adds trailing slash to destination if needed as filepath.Join removes it
-		if (len(filename) == 1 && os.IsPathSeparator(filename[0])) || filename == "" {
-			fpath = dest + string(os.PathSeparator)
-		} else {
-			fpath = filepath.Join(dest, filename)
-		}
-		// Check for ZipSlip. More Info: http://bit.ly/2MsjAWE
-		if !strings.HasPrefix(fpath, filepath.Clean(dest)+string(os.PathSeparator)) {
-			return filenames, fmt.Errorf("%s: illegal file path", fpath)
-		}
-
-		filenames = append(filenames, fpath)
-
-		if f.FileInfo().IsDir() {
-			// Make Folder
-			if err = os.MkdirAll(fpath, os.ModePerm); err != nil {
-				return filenames, err
-			}
-			continue
-		}
-
-		// Make File
-		if err = os.MkdirAll(filepath.Dir(fpath), os.ModePerm); err != nil {
-			return filenames, err
-		}
-
-		outFile, err := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, ModeReadWriteFile)
-		if err != nil {
-			return filenames, err
-		}
-
-		rc, err := f.Open()
-		if err != nil {
-			return filenames, err
-		}
-
-		// limit the number of bytes copied from a file
-		// This is set to the limit of file size in Github
-		// which is 100MB
-		limited := io.LimitReader(rc, 100*1024*1024)
-
-		_, err = io.Copy(outFile, limited)
-
-		// Close the file without defer to close before next iteration of loop
-		outFile.Close()
-		rc.Close()
-
-		if err != nil {
-			return filenames, err
-		}
-	}
-	return filenames, nil
+	return Extract(src, dest, ExtractOptions{
+		StripRootFolder: true,
+		PathToExtract:   pathToUnzip,
+	})
 }
 
 // DownloadFileWithCache downloads the file to the filepath given URL and token (if applicable)
@@ -1155,16 +1062,6 @@ func PathEqual(firstPath string, secondPath string) bool {
 	return firstAbsPath == secondAbsPath
 }
 
-// sliceContainsString checks for existence of given string in given slice
-func sliceContainsString(str string, slice []string) bool {
-	for _, b := range slice {
-		if b == str {
-			return true
-		}
-	}
-	return false
-}
-
 // AddFileToIgnoreFile adds a file to the gitignore file. It only does that if the file doesn't exist
 func AddFileToIgnoreFile(gitIgnoreFile, filename string) error {
 	return addFileToIgnoreFile(gitIgnoreFile, filename, filesystem.DefaultFs{})
@@ -1203,7 +1100,7 @@ func DisplayLog(followLog bool, rd io.ReadCloser, compName string) (err error) {
 	// else, we copy it to a buffer
 	if followLog {
 
-		c := make(chan os.Signal)
+		c := make(chan os.Signal, 1)
 		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		go func() {
 			<-c